@@ -0,0 +1,176 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Archiving
+// Signed results can be stored as envelopes and verified later, e.g. in a newline-delimited archive file.
+
+// EnvelopeVersion is the version of the Envelope format written by this package.
+const EnvelopeVersion = 1
+
+// An Envelope is the stable, versioned JSON representation of a signed result for archiving.
+type Envelope struct {
+	// The version of the envelope format, see EnvelopeVersion.
+	Version int `json:"version"`
+	// The API method that generated the result, e.g. "generateSignedIntegers". Results retrieved with GetResult
+	// have the method recorded in their random object.
+	Method string `json:"method"`
+	// The parameters of the request that generated the result, without the API key. Results retrieved with
+	// GetResult have the parameters recorded in their random object.
+	Params json.RawMessage `json:"params,omitempty"`
+	// The random object exactly as it was returned by the API. It is stored as a string so that its bytes,
+	// which the signature is computed over, are preserved.
+	Random string `json:"random"`
+	// The base64 encoded signature of the random object.
+	Signature string `json:"signature"`
+	// The serial number of the random object.
+	SerialNumber int `json:"serialNumber"`
+	// The time the result was received at.
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+// NewEnvelope returns the envelope of the signed result, e.g. of a *SignedIntegers.
+func NewEnvelope(s Signed) *Envelope {
+	signed := s.Signed()
+
+	return &Envelope{
+		Version:      EnvelopeVersion,
+		Method:       signed.requestMethod,
+		Params:       signed.requestParams,
+		Random:       string(signed.Random),
+		Signature:    signed.Signature,
+		SerialNumber: signed.SerialNumber,
+		FetchedAt:    signed.fetchedAt,
+	}
+}
+
+// MarshalEnvelope returns the JSON encoded envelope of the signed result.
+func MarshalEnvelope(s Signed) ([]byte, error) {
+	return json.Marshal(NewEnvelope(s))
+}
+
+// UnmarshalEnvelope decodes a JSON encoded envelope.
+// It returns ErrArchiveVersion if the envelope was written with an unknown format version.
+func UnmarshalEnvelope(data []byte) (*Envelope, error) {
+	envelope := &Envelope{}
+	err := json.Unmarshal(data, envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = envelope.checkVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+// checkVersion returns ErrArchiveVersion if the envelope has an unknown format version.
+func (e *Envelope) checkVersion() error {
+	if e.Version < 1 || e.Version > EnvelopeVersion {
+		return fmt.Errorf("%w: %d", ErrArchiveVersion, e.Version)
+	}
+
+	return nil
+}
+
+// Signed returns the typed signed result stored in the envelope, e.g. a *SignedIntegers.
+// Results of unknown methods are returned as *SignedResult.
+func (e *Envelope) Signed() (Signed, error) {
+	signed, data, err := parseSignedRandom(json.RawMessage(e.Random))
+	if err != nil {
+		return nil, err
+	}
+	signed.Signature = e.Signature
+	signed.requestMethod = e.Method
+	signed.requestParams = e.Params
+	signed.fetchedAt = e.FetchedAt
+
+	return newTypedSigned(signed, data)
+}
+
+// Verify verifies the signature of the archived random object offline. See Verify for details.
+func (e *Envelope) Verify(publicKey *rsa.PublicKey) error {
+	return Verify(publicKey, json.RawMessage(e.Random), e.Signature)
+}
+
+// randomRequestParams returns the request parameters recorded in the random object, i.e. all of its fields
+// that do not describe the result itself.
+func randomRequestParams(rawRandom json.RawMessage) (json.RawMessage, error) {
+	random := map[string]json.RawMessage{}
+	err := json.Unmarshal(rawRandom, &random)
+	if err != nil {
+		return nil, jsonFormatError("random object: %v", err)
+	}
+
+	for _, key := range []string{"method", "hashedApiKey", "data", "license", "completionTime", "serialNumber"} {
+		delete(random, key)
+	}
+
+	return json.Marshal(random)
+}
+
+// An ArchiveWriter writes signed results as newline-delimited envelopes.
+type ArchiveWriter struct {
+	encoder *json.Encoder
+}
+
+// NewArchiveWriter returns an ArchiveWriter writing to w.
+func NewArchiveWriter(w io.Writer) *ArchiveWriter {
+	return &ArchiveWriter{json.NewEncoder(w)}
+}
+
+// Write appends the envelope of the signed result to the archive.
+func (a *ArchiveWriter) Write(s Signed) error {
+	return a.encoder.Encode(NewEnvelope(s))
+}
+
+// An ArchiveReader reads envelopes from a newline-delimited archive written by an ArchiveWriter.
+type ArchiveReader struct {
+	decoder *json.Decoder
+}
+
+// NewArchiveReader returns an ArchiveReader reading from r.
+func NewArchiveReader(r io.Reader) *ArchiveReader {
+	return &ArchiveReader{json.NewDecoder(r)}
+}
+
+// Read returns the next envelope of the archive. It returns io.EOF when there are no more envelopes.
+func (a *ArchiveReader) Read() (*Envelope, error) {
+	envelope := &Envelope{}
+	err := a.decoder.Decode(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	err = envelope.checkVersion()
+	if err != nil {
+		return nil, err
+	}
+
+	return envelope, nil
+}
@@ -0,0 +1,87 @@
+package randomorg
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestArchive(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		return `{"random":{"method":"generateSignedIntegers","hashedApiKey":"oT3AdLMVZKajz0pgW","n":2,"min":1,"max":6,"replacement":true,"base":10,"data":[4,2],"completionTime":"2020-01-01 10:05:00Z","serialNumber":7,"userData":"<tag> & more"},"signature":"c2ln"}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	signed, err := random.GenerateSignedIntegers(2, 1, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := &bytes.Buffer{}
+	writer := NewArchiveWriter(archive)
+	for i := 0; i < 2; i++ {
+		if err := writer.Write(signed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bytes.Contains(archive.Bytes(), []byte("key")) {
+		t.Errorf("archive contains the api key: %s", archive)
+	}
+
+	reader := NewArchiveReader(archive)
+	for i := 0; i < 2; i++ {
+		envelope, err := reader.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if envelope.Version != EnvelopeVersion || envelope.Method != "generateSignedIntegers" || envelope.SerialNumber != 7 || envelope.FetchedAt.IsZero() {
+			t.Errorf("envelope = %+v", envelope)
+		}
+		if envelope.Random != string(signed.Random) {
+			t.Errorf("random = %s, want %s", envelope.Random, signed.Random)
+		}
+
+		result, err := envelope.Signed()
+		if err != nil {
+			t.Fatal(err)
+		}
+		integers, ok := result.(*SignedIntegers)
+		if !ok || len(integers.Data) != 2 || integers.Data[0] != 4 || integers.Signature != "c2ln" {
+			t.Errorf("Signed() = %#v, want *SignedIntegers", result)
+		}
+		if string(NewEnvelope(integers).Params) != string(envelope.Params) {
+			t.Errorf("params = %s, want %s", NewEnvelope(integers).Params, envelope.Params)
+		}
+	}
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("Read() at end = %v, want %v", err, io.EOF)
+	}
+
+	recovered, err := NewRandom("key", WithEndpoint(server.URL), WithAPIVersion(APIVersion4)).GetResult(7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	envelope := NewEnvelope(recovered)
+	params := map[string]interface{}{}
+	if err := json.Unmarshal(envelope.Params, &params); err != nil {
+		t.Fatal(err)
+	}
+	if envelope.Method != "generateSignedIntegers" || len(params) != 6 || params["n"] != float64(2) || params["userData"] != "<tag> & more" {
+		t.Errorf("envelope of GetResult() = %s %s, want generateSignedIntegers with its parameters", envelope.Method, envelope.Params)
+	}
+
+	if _, err := UnmarshalEnvelope([]byte(`{"version":2}`)); !errors.Is(err, ErrArchiveVersion) {
+		t.Errorf("UnmarshalEnvelope() of version 2 = %v, want %v", err, ErrArchiveVersion)
+	}
+
+	corrupt, err := UnmarshalEnvelope([]byte(`{"version":1,"method":"generateSignedIntegers","random":"{\"method\":\"generateSignedIntegers\",\"base\":0,\"data\":[5],\"completionTime\":\"2020-01-01 10:05:00Z\",\"serialNumber\":8}","signature":"c2ln","serialNumber":8}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := corrupt.Signed(); !errors.Is(err, ErrJSONFormat) {
+		t.Errorf("Signed() of corrupt base = %v, want %v", err, ErrJSONFormat)
+	}
+}
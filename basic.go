@@ -17,11 +17,292 @@
 
 package randomorg
 
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/pborman/uuid"
+)
+
 // Basic commands
 // see https://api.random.org/json-rpc/2/basic
 
 // GenerateIntegers generates n number of random integers in the range from min to max.
-func (r *Random) GenerateIntegers(n int, min, max int64) ([]int64, error) {
+func (r *Random) GenerateIntegers(n int, min, max int64, options ...GenerateOption) ([]int64, error) {
+	return r.GenerateIntegersContext(context.Background(), n, min, max, options...)
+}
+
+// GenerateIntegersContext is like GenerateIntegers but uses the given context for the request.
+func (r *Random) GenerateIntegersContext(ctx context.Context, n int, min, max int64, options ...GenerateOption) ([]int64, error) {
+	params, err := integersParams(n, min, max, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateIntegers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIntegers(values)
+}
+
+// GenerateIntegersBase generates n number of random integers in the range from min to max
+// and returns them formatted in the given base, which is one of 2, 8, 10 or 16.
+// Use ParseIntegersBase to convert them to int64 values.
+func (r *Random) GenerateIntegersBase(n int, min, max int64, base int, options ...GenerateOption) ([]string, error) {
+	return r.GenerateIntegersBaseContext(context.Background(), n, min, max, base, options...)
+}
+
+// GenerateIntegersBaseContext is like GenerateIntegersBase but uses the given context for the request.
+func (r *Random) GenerateIntegersBaseContext(ctx context.Context, n int, min, max int64, base int, options ...GenerateOption) ([]string, error) {
+	params, err := integersBaseParams(n, min, max, base, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateIntegers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIntegerStrings(values, base)
+}
+
+// ParseIntegersBase parses integers formatted in the given base, e.g. as returned by GenerateIntegersBase.
+func ParseIntegersBase(values []string, base int) ([]int64, error) {
+	ints := make([]int64, len(values))
+	for i, value := range values {
+		var err error
+		ints[i], err = strconv.ParseInt(value, base, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ints, nil
+}
+
+// GenerateIntegerSequences generates n sequences of random integers, each with length integers in the range from min to max.
+func (r *Random) GenerateIntegerSequences(n, length int, min, max int64, options ...GenerateOption) ([][]int64, error) {
+	return r.GenerateIntegerSequencesContext(context.Background(), n, length, min, max, options...)
+}
+
+// GenerateIntegerSequencesContext is like GenerateIntegerSequences but uses the given context for the request.
+func (r *Random) GenerateIntegerSequencesContext(ctx context.Context, n, length int, min, max int64, options ...GenerateOption) ([][]int64, error) {
+	if n < 1 || n > 1e3 {
+		return nil, ErrParamRange
+	}
+
+	sequences := make([]IntegerSequence, n)
+	for i := range sequences {
+		sequences[i] = IntegerSequence{Length: length, Min: min, Max: max}
+	}
+
+	return r.GenerateMultiformIntegerSequencesContext(ctx, sequences, options...)
+}
+
+// An IntegerSequence describes one of the sequences requested from GenerateMultiformIntegerSequences.
+type IntegerSequence struct {
+	// The number of integers in the sequence.
+	Length int
+	// The lower and upper boundary of the range the integers are drawn from.
+	Min, Max int64
+	// Whether the integers of the sequence are drawn without replacement, i.e. are unique.
+	WithoutReplacement bool
+	// The base the integers are transmitted in, one of 2, 8, 10 or 16. Zero means base 10.
+	Base int
+}
+
+// GenerateMultiformIntegerSequences generates sequences of random integers, each with its own length, range,
+// replacement and base.
+func (r *Random) GenerateMultiformIntegerSequences(sequences []IntegerSequence, options ...GenerateOption) ([][]int64, error) {
+	return r.GenerateMultiformIntegerSequencesContext(context.Background(), sequences, options...)
+}
+
+// GenerateMultiformIntegerSequencesContext is like GenerateMultiformIntegerSequences but uses the given context for the request.
+func (r *Random) GenerateMultiformIntegerSequencesContext(ctx context.Context, sequences []IntegerSequence, options ...GenerateOption) ([][]int64, error) {
+	params, err := integerSequencesParams(sequences, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateIntegerSequences", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIntegerSequences(values, sequences)
+}
+
+// GenerateDecimalFractions generates n number of decimal fractions with decimalPlaces number of decimal places.
+func (r *Random) GenerateDecimalFractions(n, decimalPlaces int, options ...GenerateOption) ([]float64, error) {
+	return r.GenerateDecimalFractionsContext(context.Background(), n, decimalPlaces, options...)
+}
+
+// GenerateDecimalFractionsContext is like GenerateDecimalFractions but uses the given context for the request.
+func (r *Random) GenerateDecimalFractionsContext(ctx context.Context, n, decimalPlaces int, options ...GenerateOption) ([]float64, error) {
+	params, err := decimalFractionsParams(n, decimalPlaces, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateDecimalFractions", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFloats(values)
+}
+
+// GaussianParams holds the parameters of a request for numbers from a Gaussian distribution.
+type GaussianParams struct {
+	// The number of values to generate.
+	N int
+	// The mean of the distribution, in the range from -1e6 to 1e6.
+	Mean float64
+	// The standard deviation of the distribution, in the range from 0 to 1e6.
+	StandardDeviation float64
+	// The number of significant digits of the values, in the range from 2 to 20.
+	SignificantDigits int
+}
+
+// GenerateGaussians generates true random numbers from a Gaussian distribution.
+//
+// Deprecated: GenerateGaussians only accepts an integral mean and standard deviation. Use GenerateGaussiansWithParams.
+func (r *Random) GenerateGaussians(n, mean, standardDeviation, significantDigits int, options ...GenerateOption) ([]float64, error) {
+	return r.GenerateGaussiansContext(context.Background(), n, mean, standardDeviation, significantDigits, options...)
+}
+
+// GenerateGaussiansContext is like GenerateGaussians but uses the given context for the request.
+//
+// Deprecated: Use GenerateGaussiansWithParamsContext.
+func (r *Random) GenerateGaussiansContext(ctx context.Context, n, mean, standardDeviation, significantDigits int, options ...GenerateOption) ([]float64, error) {
+	return r.GenerateGaussiansWithParamsContext(ctx, GaussianParams{n, float64(mean), float64(standardDeviation), significantDigits}, options...)
+}
+
+// GenerateGaussiansWithParams generates true random numbers from a Gaussian distribution.
+func (r *Random) GenerateGaussiansWithParams(p GaussianParams, options ...GenerateOption) ([]float64, error) {
+	return r.GenerateGaussiansWithParamsContext(context.Background(), p, options...)
+}
+
+// GenerateGaussiansWithParamsContext is like GenerateGaussiansWithParams but uses the given context for the request.
+func (r *Random) GenerateGaussiansWithParamsContext(ctx context.Context, p GaussianParams, options ...GenerateOption) ([]float64, error) {
+	params, err := gaussiansParams(p, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateGaussians", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseFloats(values)
+}
+
+// GenerateStrings generates n random strings with the given length composed from the characters.
+// The length is at most 32 and characters must hold 1 to 80 distinct characters, see the Charset constants.
+func (r *Random) GenerateStrings(n, length int, characters string, options ...GenerateOption) ([]string, error) {
+	return r.GenerateStringsContext(context.Background(), n, length, characters, options...)
+}
+
+// GenerateStringsContext is like GenerateStrings but uses the given context for the request.
+func (r *Random) GenerateStringsContext(ctx context.Context, n, length int, characters string, options ...GenerateOption) ([]string, error) {
+	params, err := stringsParams(n, length, characters, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateStrings", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStrings(values)
+}
+
+// GenerateUUIDs generates n random version 4 Universally Unique Identifiers (see section 4.4 of RFC 4122)
+func (r *Random) GenerateUUIDs(n int, options ...GenerateOption) ([]string, error) {
+	return r.GenerateUUIDsContext(context.Background(), n, options...)
+}
+
+// GenerateUUIDsContext is like GenerateUUIDs but uses the given context for the request.
+func (r *Random) GenerateUUIDsContext(ctx context.Context, n int, options ...GenerateOption) ([]string, error) {
+	params, err := uuidsParams(n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateUUIDs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStrings(values)
+}
+
+// GenerateUUIDValues is like GenerateUUIDs but returns the parsed UUIDs.
+// It fails if the API returned a value that is not a RFC 4122 version 4 UUID.
+func (r *Random) GenerateUUIDValues(n int, options ...GenerateOption) ([]uuid.UUID, error) {
+	return r.GenerateUUIDValuesContext(context.Background(), n, options...)
+}
+
+// GenerateUUIDValuesContext is like GenerateUUIDValues but uses the given context for the request.
+func (r *Random) GenerateUUIDValuesContext(ctx context.Context, n int, options ...GenerateOption) ([]uuid.UUID, error) {
+	uuids, err := r.GenerateUUIDsContext(ctx, n, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseUUIDs(uuids)
+}
+
+// ParseUUIDs parses RFC 4122 version 4 UUIDs, e.g. the data of SignedUUIDs.
+func ParseUUIDs(values []string) ([]uuid.UUID, error) {
+	uuids := make([]uuid.UUID, len(values))
+	for i, value := range values {
+		parsed := uuid.Parse(value)
+		if parsed == nil {
+			return nil, jsonFormatError("value %d is %q, want UUID", i, value)
+		}
+		version, ok := parsed.Version()
+		if !ok || version != 4 || parsed.Variant() != uuid.RFC4122 {
+			return nil, jsonFormatError("value %d is %q, want RFC 4122 version 4 UUID", i, value)
+		}
+		uuids[i] = parsed
+	}
+
+	return uuids, nil
+}
+
+// GenerateBlobs generates n random blobs of size bits, encoded as base64 unless the WithBlobFormat option is given.
+func (r *Random) GenerateBlobs(n, size int, options ...GenerateOption) ([]string, error) {
+	return r.GenerateBlobsContext(context.Background(), n, size, options...)
+}
+
+// GenerateBlobsContext is like GenerateBlobs but uses the given context for the request.
+func (r *Random) GenerateBlobsContext(ctx context.Context, n, size int, options ...GenerateOption) ([]string, error) {
+	params, err := blobsParams(n, size, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateBlobs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseStrings(values)
+}
+
+// Parameters
+// The basic and signed methods share their parameters and value ranges.
+
+func integersParams(n int, min, max int64, options []GenerateOption) (map[string]interface{}, error) {
 	if n < 1 || n > 1e4 {
 		return nil, ErrParamRange
 	}
@@ -29,28 +310,131 @@ func (r *Random) GenerateIntegers(n int, min, max int64) ([]int64, error) {
 		return nil, ErrParamRange
 	}
 
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkBlobFormat()
+	if err != nil {
+		return nil, err
+	}
+	// unique values have to fit into the range
+	if o.withoutReplacement && int64(n) > max-min+1 {
+		return nil, ErrParamRange
+	}
+
 	params := map[string]interface{}{
 		"n":   n,
 		"min": min,
 		"max": max,
 	}
+	o.setReplacement(params)
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
 
-	values, err := r.requestCommand("generateIntegers", params)
+	return params, nil
+}
+
+func integersBaseParams(n int, min, max int64, base int, options []GenerateOption) (map[string]interface{}, error) {
+	if !validBase(base) {
+		return nil, ErrParamRange
+	}
+
+	params, err := integersParams(n, min, max, options)
 	if err != nil {
 		return nil, err
 	}
+	params["base"] = base
 
-	ints := make([]int64, len(values))
-	for i, value := range values {
-		f := value.(float64)
-		ints[i] = int64(f)
+	return params, nil
+}
+
+func integerSequencesParams(sequences []IntegerSequence, options []GenerateOption) (map[string]interface{}, error) {
+	if len(sequences) < 1 || len(sequences) > 1e3 {
+		return nil, ErrParamRange
 	}
 
-	return ints, nil
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkBlobFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	total := 0
+	uniform := true
+	lengths := make([]int, len(sequences))
+	mins := make([]int64, len(sequences))
+	maxs := make([]int64, len(sequences))
+	replacements := make([]bool, len(sequences))
+	bases := make([]int, len(sequences))
+	for i, sequence := range sequences {
+		if sequence.Length < 1 || sequence.Length > 1e4 {
+			return nil, ErrParamRange
+		}
+		if sequence.Min < -1e9 || sequence.Min > 1e9 || sequence.Max < -1e9 || sequence.Max > 1e9 {
+			return nil, ErrParamRange
+		}
+		withoutReplacement := sequence.WithoutReplacement || o.withoutReplacement
+		// unique values have to fit into the range
+		if withoutReplacement && int64(sequence.Length) > sequence.Max-sequence.Min+1 {
+			return nil, ErrParamRange
+		}
+		base := sequence.Base
+		if base == 0 {
+			base = 10
+		}
+		if !validBase(base) {
+			return nil, ErrParamRange
+		}
+
+		total += sequence.Length
+		lengths[i] = sequence.Length
+		mins[i] = sequence.Min
+		maxs[i] = sequence.Max
+		replacements[i] = !withoutReplacement
+		bases[i] = base
+		if lengths[i] != lengths[0] || mins[i] != mins[0] || maxs[i] != maxs[0] || replacements[i] != replacements[0] || bases[i] != bases[0] {
+			uniform = false
+		}
+	}
+	// the total number of integers is limited as well
+	if total > 1e4 {
+		return nil, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"n": len(sequences),
+	}
+	if uniform {
+		params["length"] = lengths[0]
+		params["min"] = mins[0]
+		params["max"] = maxs[0]
+		if !replacements[0] {
+			params["replacement"] = false
+		}
+		if bases[0] != 10 {
+			params["base"] = bases[0]
+		}
+	} else {
+		params["length"] = lengths
+		params["min"] = mins
+		params["max"] = maxs
+		params["replacement"] = replacements
+		params["base"] = bases
+	}
+
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
+
+	return params, nil
 }
 
-// GenerateDecimalFractions generates n number of decimal fractions with decimalPlaces number of decimal places.
-func (r *Random) GenerateDecimalFractions(n, decimalPlaces int) ([]float64, error) {
+func decimalFractionsParams(n, decimalPlaces int, options []GenerateOption) (map[string]interface{}, error) {
 	if n < 1 || n > 1e4 {
 		return nil, ErrParamRange
 	}
@@ -58,68 +442,95 @@ func (r *Random) GenerateDecimalFractions(n, decimalPlaces int) ([]float64, erro
 		return nil, ErrParamRange
 	}
 
-	params := map[string]interface{}{
-		"n":             n,
-		"decimalPlaces": decimalPlaces,
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
 	}
-
-	values, err := r.requestCommand("generateDecimalFractions", params)
+	err = o.checkBlobFormat()
 	if err != nil {
 		return nil, err
 	}
+	// unique values have to fit into the 10^decimalPlaces possible fractions
+	if o.withoutReplacement && !powerAtLeast(10, decimalPlaces, n) {
+		return nil, ErrParamRange
+	}
 
-	decimals := make([]float64, len(values))
-	for i, value := range values {
-		decimals[i] = value.(float64)
+	params := map[string]interface{}{
+		"n":             n,
+		"decimalPlaces": decimalPlaces,
 	}
+	o.setReplacement(params)
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
 
-	return decimals, nil
+	return params, nil
 }
 
-// GenerateGaussians generates true random numbers from a Gaussian distribution.
-func (r *Random) GenerateGaussians(n, mean, standardDeviation, significantDigits int) ([]float64, error) {
-	if n < 1 || n > 1e4 {
+func gaussiansParams(p GaussianParams, options []GenerateOption) (map[string]interface{}, error) {
+	if p.N < 1 || p.N > 1e4 {
 		return nil, ErrParamRange
 	}
-	if mean < -1e6 || mean > 1e6 {
+	// negated so that NaN is rejected as well
+	if !(p.Mean >= -1e6 && p.Mean <= 1e6) {
 		return nil, ErrParamRange
 	}
-	if standardDeviation < -1e6 || standardDeviation > 1e6 {
+	if !(p.StandardDeviation >= 0 && p.StandardDeviation <= 1e6) {
 		return nil, ErrParamRange
 	}
-	if significantDigits < 2 || significantDigits > 20 {
+	if p.SignificantDigits < 2 || p.SignificantDigits > 20 {
 		return nil, ErrParamRange
 	}
 
-	params := map[string]interface{}{
-		"n":                 n,
-		"mean":              mean,
-		"standardDeviation": standardDeviation,
-		"significantDigits": significantDigits,
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
 	}
-
-	values, err := r.requestCommand("generateGaussians", params)
+	err = o.checkBlobFormat()
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkReplacement()
 	if err != nil {
 		return nil, err
 	}
 
-	gaussians := make([]float64, len(values))
-	for i, value := range values {
-		gaussians[i] = value.(float64)
+	params := map[string]interface{}{
+		"n":                 p.N,
+		"mean":              p.Mean,
+		"standardDeviation": p.StandardDeviation,
+		"significantDigits": p.SignificantDigits,
 	}
 
-	return gaussians, nil
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
+
+	return params, nil
 }
 
-// GenerateStrings generates n random strings with the given length composed from the characters.
-func (r *Random) GenerateStrings(n, length int, characters string) ([]string, error) {
+func stringsParams(n, length int, characters string, options []GenerateOption) (map[string]interface{}, error) {
 	if n < 1 || n > 1e4 {
 		return nil, ErrParamRange
 	}
-	if length < 1 || length > 20 {
+	if length < 1 || length > 32 {
 		return nil, ErrParamRange
 	}
-	if len(characters) < 1 || len(characters) > 80 {
+	err := validateCharacters(characters)
+	if err != nil {
+		return nil, err
+	}
+
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkBlobFormat()
+	if err != nil {
+		return nil, err
+	}
+	// unique values have to fit into the count(characters)^length possible strings
+	if o.withoutReplacement && !powerAtLeast(utf8.RuneCountInString(characters), length, n) {
 		return nil, ErrParamRange
 	}
 
@@ -128,66 +539,232 @@ func (r *Random) GenerateStrings(n, length int, characters string) ([]string, er
 		"length":     length,
 		"characters": characters,
 	}
+	o.setReplacement(params)
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
 
-	values, err := r.requestCommand("generateStrings", params)
-	if err != nil {
-		return nil, err
+	return params, nil
+}
+
+// validateCharacters checks that characters is a set of 1 to 80 distinct characters.
+func validateCharacters(characters string) error {
+	if !utf8.ValidString(characters) {
+		return fmt.Errorf("%w: characters are not valid UTF-8", ErrParamRange)
 	}
 
-	strings := make([]string, len(values))
-	for i, value := range values {
-		strings[i] = value.(string)
+	count := utf8.RuneCountInString(characters)
+	if count < 1 || count > 80 {
+		return ErrParamRange
 	}
 
-	return strings, nil
+	seen := make(map[rune]bool, count)
+	for _, c := range characters {
+		if seen[c] {
+			return fmt.Errorf("%w: character %q appears more than once", ErrParamRange, c)
+		}
+		seen[c] = true
+	}
+
+	return nil
 }
 
-// GenerateUUIDs generates n random version 4 Universally Unique Identifiers (see section 4.4 of RFC 4122)
-func (r *Random) GenerateUUIDs(n int) ([]string, error) {
+func uuidsParams(n int, options []GenerateOption) (map[string]interface{}, error) {
 	if n < 1 || n > 1e3 {
 		return nil, ErrParamRange
 	}
 
-	params := map[string]interface{}{
-		"n": n,
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
 	}
-
-	values, err := r.requestCommand("generateUUIDs", params)
+	err = o.checkBlobFormat()
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkReplacement()
 	if err != nil {
 		return nil, err
 	}
 
-	uuids := make([]string, len(values))
-	for i, value := range values {
-		uuids[i] = value.(string)
+	params := map[string]interface{}{
+		"n": n,
 	}
 
-	return uuids, nil
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
+
+	return params, nil
 }
 
-// GenerateBlobs generates n random blobs of size.
-func (r *Random) GenerateBlobs(n, size int) ([]string, error) {
+func blobsParams(n, size int, options []GenerateOption) (map[string]interface{}, error) {
 	if n < 1 || n > 100 {
 		return nil, ErrParamRange
 	}
 	if size < 1 || size > 1048576 || size%8 != 0 {
 		return nil, ErrParamRange
 	}
+	// the total size of all blobs is limited as well
+	if n*size > 1048576 {
+		return nil, ErrParamRange
+	}
+
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+	err = o.checkReplacement()
+	if err != nil {
+		return nil, err
+	}
+	if o.blobFormat != "" && o.blobFormat != BlobFormatBase64 && o.blobFormat != BlobFormatHex {
+		return nil, ErrParamRange
+	}
 
 	params := map[string]interface{}{
 		"n":    n,
 		"size": size,
 	}
+	if o.blobFormat != "" {
+		params["format"] = o.blobFormat
+	}
 
-	values, err := r.requestCommand("generateBlobs", params)
-	if err != nil {
-		return nil, err
+	o.setPregeneratedRandomization(params)
+	o.setTicketID(params)
+	o.setSignedData(params)
+
+	return params, nil
+}
+
+// validBase reports whether integers can be requested in the given base.
+func validBase(base int) bool {
+	return base == 2 || base == 8 || base == 10 || base == 16
+}
+
+// powerAtLeast reports whether base^exponent is at least n.
+func powerAtLeast(base, exponent, n int) bool {
+	count := 1
+	for i := 0; i < exponent; i++ {
+		count *= base
+		if count >= n {
+			return true
+		}
+	}
+
+	return count >= n
+}
+
+// Values
+
+func parseIntegers(values []interface{}) ([]int64, error) {
+	ints := make([]int64, len(values))
+	for i, value := range values {
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, jsonFormatError("value %d is %T, want integer", i, value)
+		}
+		var err error
+		ints[i], err = number.Int64()
+		if err != nil {
+			return nil, jsonFormatError("value %d is %v, want integer", i, value)
+		}
+	}
+
+	return ints, nil
+}
+
+// parseIntegerStrings parses integers in the given base. The API returns strings for all bases but 10.
+func parseIntegerStrings(values []interface{}, base int) ([]string, error) {
+	if !validBase(base) {
+		return nil, jsonFormatError("base is %d, want 2, 8, 10 or 16", base)
 	}
 
-	blobs := make([]string, len(values))
+	strs := make([]string, len(values))
 	for i, value := range values {
-		blobs[i] = value.(string)
+		switch v := value.(type) {
+		case string:
+			_, err := strconv.ParseInt(v, base, 64)
+			if err != nil {
+				return nil, jsonFormatError("value %d is %q, want base %d integer", i, v, base)
+			}
+			strs[i] = v
+		case json.Number:
+			integer, err := v.Int64()
+			if err != nil {
+				return nil, jsonFormatError("value %d is %v, want integer", i, v)
+			}
+			strs[i] = strconv.FormatInt(integer, base)
+		default:
+			return nil, jsonFormatError("value %d is %T, want integer", i, value)
+		}
+	}
+
+	return strs, nil
+}
+
+// parseIntegerSequences parses the integer sequences with the bases they were requested in.
+func parseIntegerSequences(values []interface{}, sequences []IntegerSequence) ([][]int64, error) {
+	if len(values) != len(sequences) {
+		return nil, jsonFormatError("got %d sequences, want %d", len(values), len(sequences))
 	}
 
-	return blobs, nil
+	ints := make([][]int64, len(values))
+	for i, value := range values {
+		sequence, ok := value.([]interface{})
+		if !ok {
+			return nil, jsonFormatError("sequence %d is %T, want array", i, value)
+		}
+
+		base := sequences[i].Base
+		if base == 0 || base == 10 {
+			var err error
+			ints[i], err = parseIntegers(sequence)
+			if err != nil {
+				return nil, fmt.Errorf("sequence %d: %w", i, err)
+			}
+			continue
+		}
+
+		strs, err := parseIntegerStrings(sequence, base)
+		if err != nil {
+			return nil, fmt.Errorf("sequence %d: %w", i, err)
+		}
+		ints[i], err = ParseIntegersBase(strs, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return ints, nil
+}
+
+func parseFloats(values []interface{}) ([]float64, error) {
+	floats := make([]float64, len(values))
+	for i, value := range values {
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, jsonFormatError("value %d is %T, want number", i, value)
+		}
+		var err error
+		floats[i], err = number.Float64()
+		if err != nil {
+			return nil, jsonFormatError("value %d is %v, want number", i, value)
+		}
+	}
+
+	return floats, nil
+}
+
+func parseStrings(values []interface{}) ([]string, error) {
+	strings := make([]string, len(values))
+	for i, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return nil, jsonFormatError("value %d is %T, want string", i, value)
+		}
+		strings[i] = s
+	}
+
+	return strings, nil
 }
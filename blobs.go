@@ -0,0 +1,82 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// A BlobFormat is an encoding blobs are returned in.
+type BlobFormat string
+
+// Supported blob formats.
+const (
+	// BlobFormatBase64 encodes blobs as base64. This is the API default.
+	BlobFormatBase64 BlobFormat = "base64"
+	// BlobFormatHex encodes blobs as hexadecimal strings.
+	BlobFormatHex BlobFormat = "hex"
+)
+
+// GenerateBlobBytes generates n random blobs of sizeBytes bytes and returns them decoded.
+func (r *Random) GenerateBlobBytes(n, sizeBytes int, options ...GenerateOption) ([][]byte, error) {
+	return r.GenerateBlobBytesContext(context.Background(), n, sizeBytes, options...)
+}
+
+// GenerateBlobBytesContext is like GenerateBlobBytes but uses the given context for the request.
+func (r *Random) GenerateBlobBytesContext(ctx context.Context, n, sizeBytes int, options ...GenerateOption) ([][]byte, error) {
+	if sizeBytes < 1 || sizeBytes > 1048576/8 {
+		return nil, ErrParamRange
+	}
+
+	o, err := newGenerateOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs, err := r.GenerateBlobsContext(ctx, n, sizeBytes*8, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeBlobs(blobs, o.blobFormat)
+}
+
+// DecodeBlobs decodes blobs encoded in the given format, e.g. the data of SignedBlobs.
+// An empty format means the API default, BlobFormatBase64.
+func DecodeBlobs(blobs []string, format BlobFormat) ([][]byte, error) {
+	decoded := make([][]byte, len(blobs))
+	for i, blob := range blobs {
+		var err error
+		switch format {
+		case "", BlobFormatBase64:
+			decoded[i], err = base64.StdEncoding.DecodeString(blob)
+		case BlobFormatHex:
+			decoded[i], err = hex.DecodeString(blob)
+		default:
+			return nil, ErrParamRange
+		}
+		if err != nil {
+			return nil, fmt.Errorf("blob %d: %w", i, err)
+		}
+	}
+
+	return decoded, nil
+}
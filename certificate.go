@@ -0,0 +1,116 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"encoding/base64"
+	"errors"
+	"html/template"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Verification form
+// random.org lets anyone verify a signed result with a web form, so a result can be shared as a link or a page.
+
+// Private constants
+const (
+	// The URL of random.org's signature verification form
+	verificationFormURL = "https://api.random.org/signatures/form"
+	// The maximum length of a verification URL that browsers reliably accept
+	maxVerificationURLLength = 2046
+)
+
+// ErrURLLength is returned by VerificationURL when the URL would be too long for browsers.
+// Use VerificationForm or WriteCertificate for such results instead.
+var ErrURLLength = errors.New("verification url too long")
+
+// VerificationForm returns the fields to POST to random.org's verification form at
+// https://api.random.org/signatures/form to verify the signed result.
+func (s *SignedResult) VerificationForm() url.Values {
+	return url.Values{
+		"format":    {"json"},
+		"random":    {string(s.Random)},
+		"signature": {s.Signature},
+	}
+}
+
+// VerificationURL returns a link to random.org's verification form that verifies the signed result when opened.
+// It returns ErrURLLength if the result is too large for a link.
+func (s *SignedResult) VerificationURL() (string, error) {
+	query := url.Values{
+		"format":    {"json"},
+		"random":    {base64.StdEncoding.EncodeToString(s.Random)},
+		"signature": {s.Signature},
+	}
+	verificationURL := verificationFormURL + "?" + query.Encode()
+	if len(verificationURL) > maxVerificationURLLength {
+		return "", ErrURLLength
+	}
+
+	return verificationURL, nil
+}
+
+// certificateTemplate renders a self-contained HTML page showing a signed result along with a button
+// that submits it to random.org's verification form.
+var certificateTemplate = template.Must(template.New("certificate").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 50em; margin: 2em auto; }
+pre { white-space: pre-wrap; word-break: break-all; background: #f4f4f4; padding: 1em; }
+th { text-align: left; padding-right: 1em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<table>
+<tr><th>Method</th><td>{{.Result.Method}}</td></tr>
+<tr><th>Serial number</th><td>{{.Result.SerialNumber}}</td></tr>
+<tr><th>Completion time</th><td>{{.Result.CompletionTime.UTC.Format "2006-01-02 15:04:05Z"}}</td></tr>
+</table>
+<h2>Random object</h2>
+<pre>{{printf "%s" .Result.Random}}</pre>
+<h2>Signature</h2>
+<pre>{{.Result.Signature}}</pre>
+<form action="{{.Action}}" method="post">
+{{range $name, $values := .Form}}{{range $values}}<input type="hidden" name="{{$name}}" value="{{.}}">
+{{end}}{{end}}<input type="submit" value="Verify with random.org">
+</form>
+</body>
+</html>
+`))
+
+// WriteCertificate writes a self-contained HTML certificate of the signed result to w. The certificate shows the
+// random object and its signature and lets the reader verify them with random.org's verification form.
+// An empty title defaults to "Signed Random.org Result".
+func (s *SignedResult) WriteCertificate(w io.Writer, title string) error {
+	if strings.TrimSpace(title) == "" {
+		title = "Signed Random.org Result"
+	}
+
+	return certificateTemplate.Execute(w, struct {
+		Title  string
+		Result *SignedResult
+		Action string
+		Form   url.Values
+	}{title, s, verificationFormURL, s.VerificationForm()})
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+// Character sets for GenerateStrings.
+const (
+	// CharsetAlphanumeric holds the digits and the lower and upper case letters.
+	CharsetAlphanumeric = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// CharsetHex holds the lower case hexadecimal digits.
+	CharsetHex = "0123456789abcdef"
+	// CharsetBase32 holds the RFC 4648 base32 alphabet.
+	CharsetBase32 = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	// CharsetUnambiguous holds the alphanumeric characters that are not easily confused when read,
+	// i.e. all but 0, 1, I, O and l.
+	CharsetUnambiguous = "23456789abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+)
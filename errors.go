@@ -0,0 +1,87 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"fmt"
+)
+
+// An APIError is an error returned by the Random.org API.
+// See https://api.random.org/json-rpc/2/error-codes for the documented error codes.
+//
+// APIErrors with the same code are considered equal by errors.Is, so a returned error can be compared
+// with the predefined values below, e.g. errors.Is(err, ErrInsufficientBits).
+type APIError struct {
+	// The numeric error code.
+	Code int `json:"code"`
+	// The human-readable error message.
+	Message string `json:"message"`
+	// The values that were substituted into the error message, if any.
+	Data []interface{} `json:"data"`
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf(errAPI, e.Code, e.Message)
+}
+
+// Is reports whether target is an *APIError with the same code.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	return ok && t.Code == e.Code
+}
+
+// IsParameterError reports whether the error was caused by invalid request parameters.
+func (e *APIError) IsParameterError() bool {
+	return e.Code == ErrInvalidParams.Code || (e.Code >= 200 && e.Code < 400)
+}
+
+// An HTTPError is returned when the API responded with an unsuccessful HTTP status and no JSON-RPC error.
+type HTTPError struct {
+	// The HTTP status code.
+	StatusCode int
+	// The response body.
+	Body string
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("HTTP Error %d: %q.", e.StatusCode, e.Body)
+}
+
+// Documented API errors.
+var (
+	// ErrInvalidRequest is returned when the JSON sent was not a valid request object.
+	ErrInvalidRequest = &APIError{Code: -32600, Message: "Invalid Request"}
+	// ErrMethodNotFound is returned when the method does not exist or is not available.
+	ErrMethodNotFound = &APIError{Code: -32601, Message: "Method not found"}
+	// ErrInvalidParams is returned when the method parameters were invalid.
+	ErrInvalidParams = &APIError{Code: -32602, Message: "Invalid params"}
+	// ErrInternal is returned when the API encountered an internal error.
+	ErrInternal = &APIError{Code: -32603, Message: "Internal error"}
+	// ErrKeyNotExist is returned when the API key does not exist.
+	ErrKeyNotExist = &APIError{Code: 400, Message: "The API key you specified does not exist"}
+	// ErrKeyNotRunning is returned when the API key is not running.
+	ErrKeyNotRunning = &APIError{Code: 401, Message: "The API key you specified is not running"}
+	// ErrInsufficientRequests is returned when the API key has exceeded its daily request allowance.
+	ErrInsufficientRequests = &APIError{Code: 402, Message: "The API key you specified has exceeded its daily request allowance"}
+	// ErrInsufficientBits is returned when the API key has exceeded its daily bit allowance.
+	ErrInsufficientBits = &APIError{Code: 403, Message: "The API key you specified has exceeded its daily bit allowance"}
+	// ErrKeyNotValidForMethod is returned when the API key is not valid for the method.
+	ErrKeyNotValidForMethod = &APIError{Code: 404, Message: "The API key you specified is not valid for the method you requested"}
+)
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+)
+
+// Exact decimals
+// float64 cannot represent all of the up to 20 decimal places or significant digits the API returns.
+// These methods return the values exactly as they were transmitted.
+
+// GenerateDecimalFractionsRat is like GenerateDecimalFractions but returns the exact values.
+func (r *Random) GenerateDecimalFractionsRat(n, decimalPlaces int, options ...GenerateOption) ([]*big.Rat, error) {
+	return r.GenerateDecimalFractionsRatContext(context.Background(), n, decimalPlaces, options...)
+}
+
+// GenerateDecimalFractionsRatContext is like GenerateDecimalFractionsRat but uses the given context for the request.
+func (r *Random) GenerateDecimalFractionsRatContext(ctx context.Context, n, decimalPlaces int, options ...GenerateOption) ([]*big.Rat, error) {
+	params, err := decimalFractionsParams(n, decimalPlaces, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateDecimalFractions", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRats(values)
+}
+
+// GenerateGaussiansRat is like GenerateGaussiansWithParams but returns the exact values.
+func (r *Random) GenerateGaussiansRat(p GaussianParams, options ...GenerateOption) ([]*big.Rat, error) {
+	return r.GenerateGaussiansRatContext(context.Background(), p, options...)
+}
+
+// GenerateGaussiansRatContext is like GenerateGaussiansRat but uses the given context for the request.
+func (r *Random) GenerateGaussiansRatContext(ctx context.Context, p GaussianParams, options ...GenerateOption) ([]*big.Rat, error) {
+	params, err := gaussiansParams(p, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := r.requestCommand(ctx, "generateGaussians", params)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRats(values)
+}
+
+func parseRats(values []interface{}) ([]*big.Rat, error) {
+	rats := make([]*big.Rat, len(values))
+	for i, value := range values {
+		number, ok := value.(json.Number)
+		if !ok {
+			return nil, jsonFormatError("value %d is %T, want number", i, value)
+		}
+		rat, ok := new(big.Rat).SetString(number.String())
+		if !ok {
+			return nil, jsonFormatError("value %d is %v, want number", i, value)
+		}
+		rats[i] = rat
+	}
+
+	return rats, nil
+}
@@ -23,3 +23,21 @@ func ExampleRandom_GenerateIntegers() {
 	value, _ := random.GenerateIntegers(1, 0, 10)
 	fmt.Printf("Random value: %v\n", value)
 }
+
+// Create a client without panicking on an empty api key.
+func ExampleNew() {
+	random, err := randomorg.New(apiKey)
+	if err != nil {
+		fmt.Printf("Could not create client: %v\n", err)
+		return
+	}
+	// call methods on random here
+	_ = random
+}
+
+// Draw six unique numbers from 1 to 49.
+func ExampleWithoutReplacement() {
+	random := randomorg.NewRandom(apiKey)
+	values, _ := random.GenerateIntegers(6, 1, 49, randomorg.WithoutReplacement())
+	fmt.Printf("Lottery numbers: %v\n", values)
+}
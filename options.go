@@ -0,0 +1,268 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// An Option configures a Random client created by NewRandom.
+type Option func(*Random)
+
+// WithEndpoint sets the URL of the JSON-RPC endpoint requests are sent to, e.g. a gateway or a local test server.
+func WithEndpoint(endpoint string) Option {
+	return func(r *Random) {
+		r.endpoint = endpoint
+	}
+}
+
+// WithAPIVersion sets the API release requests are made against. The default is APIVersion2.
+// Unless WithEndpoint is given as well, the endpoint of the release is used.
+func WithAPIVersion(version APIVersion) Option {
+	return func(r *Random) {
+		r.version = version
+	}
+}
+
+// WithHTTPClient sets the http.Client used for requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(r *Random) {
+		if client != nil {
+			r.client = client
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with requests.
+func WithUserAgent(userAgent string) Option {
+	return func(r *Random) {
+		r.userAgent = userAgent
+	}
+}
+
+// WithTimeout sets the time limit of a single request. A timeout of zero means no timeout.
+// The deadline of a context passed to a method still applies.
+func WithTimeout(timeout time.Duration) Option {
+	return func(r *Random) {
+		r.timeout = timeout
+	}
+}
+
+// WithPacing sets whether requests wait for the advisory delay returned with the previous response.
+// Pacing is enabled by default; when disabled, Random.AdvisoryDelay can be used to pace requests manually.
+func WithPacing(enabled bool) Option {
+	return func(r *Random) {
+		r.pacing = enabled
+	}
+}
+
+// WithRetryPolicy sets the policy failed requests are retried with. By default, requests are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(r *Random) {
+		r.retry = policy
+	}
+}
+
+// A GenerateOption sets an optional parameter of a generate method.
+type GenerateOption func(*generateOptions)
+
+// generateOptions holds the optional parameters of a generate method.
+type generateOptions struct {
+	// whether values are drawn without replacement, i.e. are unique
+	withoutReplacement bool
+	// the encoding of blobs, if not the API default
+	blobFormat BlobFormat
+	// the pregenerated randomization to use, if any
+	pregeneratedRandomization map[string]string
+	// the id of the ticket to use, if any
+	ticketID string
+	// the JSON encoded user data and license data, if any
+	userData    json.RawMessage
+	licenseData json.RawMessage
+	// the first error of an invalid option
+	err error
+}
+
+// newGenerateOptions applies the given options. It returns the error of the first invalid option.
+func newGenerateOptions(options []GenerateOption) (*generateOptions, error) {
+	o := &generateOptions{}
+	for _, option := range options {
+		option(o)
+	}
+
+	return o, o.err
+}
+
+// setError records err unless an earlier option was already invalid.
+func (o *generateOptions) setError(err error) {
+	if o.err == nil {
+		o.err = err
+	}
+}
+
+// setTicketID sets the ticketId parameter if the WithTicket option was given.
+func (o *generateOptions) setTicketID(params map[string]interface{}) {
+	if o.ticketID != "" {
+		params["ticketId"] = o.ticketID
+	}
+}
+
+// setSignedData sets the userData and licenseData parameters if their options were given.
+func (o *generateOptions) setSignedData(params map[string]interface{}) {
+	if o.userData != nil {
+		params["userData"] = o.userData
+	}
+	if o.licenseData != nil {
+		params["licenseData"] = o.licenseData
+	}
+}
+
+// setPregeneratedRandomization sets the pregeneratedRandomization parameter if one of its options was given.
+func (o *generateOptions) setPregeneratedRandomization(params map[string]interface{}) {
+	if o.pregeneratedRandomization != nil {
+		params["pregeneratedRandomization"] = o.pregeneratedRandomization
+	}
+}
+
+// setReplacement sets the replacement parameter if it differs from the API default.
+func (o *generateOptions) setReplacement(params map[string]interface{}) {
+	if o.withoutReplacement {
+		params["replacement"] = false
+	}
+}
+
+// checkReplacement returns ErrParamRange if WithoutReplacement was given to a method that does not support it.
+func (o *generateOptions) checkReplacement() error {
+	if o.withoutReplacement {
+		return fmt.Errorf("%w: values of this method cannot be drawn without replacement", ErrParamRange)
+	}
+
+	return nil
+}
+
+// checkBlobFormat returns ErrParamRange if WithBlobFormat was given to a method that does not return blobs.
+func (o *generateOptions) checkBlobFormat() error {
+	if o.blobFormat != "" {
+		return fmt.Errorf("%w: only blobs have a format", ErrParamRange)
+	}
+
+	return nil
+}
+
+// WithoutReplacement requests values drawn without replacement, i.e. all values of the result are unique.
+// It is supported by the integer, integer sequence, decimal fraction and string methods; other methods return
+// ErrParamRange. The number of requested values must not exceed the number of possible values.
+func WithoutReplacement() GenerateOption {
+	return func(o *generateOptions) {
+		o.withoutReplacement = true
+	}
+}
+
+// WithBlobFormat sets the encoding blobs are returned in. It is supported by the blob methods; other methods
+// return ErrParamRange.
+func WithBlobFormat(format BlobFormat) GenerateOption {
+	return func(o *generateOptions) {
+		o.blobFormat = format
+	}
+}
+
+// WithPregeneratedDate requests the randomness random.org pregenerated for the given day instead of fresh randomness,
+// so that a request with the same parameters can be replayed to obtain the same values.
+// The date has the form YYYY-MM-DD and must be a day in the past, on or after 2010-01-01.
+// It is supported by all generate methods of API release 4.
+func WithPregeneratedDate(date string) GenerateOption {
+	return func(o *generateOptions) {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			o.setError(fmt.Errorf("%w: pregenerated date %q is not of the form YYYY-MM-DD", ErrParamRange, date))
+			return
+		}
+		today := time.Now().UTC().Truncate(24 * time.Hour)
+		if day.Before(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)) || !day.Before(today) {
+			o.setError(fmt.Errorf("%w: pregenerated date %q is not between 2010-01-01 and yesterday", ErrParamRange, date))
+			return
+		}
+
+		o.pregeneratedRandomization = map[string]string{"date": date}
+	}
+}
+
+// WithPregeneratedID requests the randomness random.org pregenerated for the given identifier instead of fresh randomness,
+// so that a request with the same parameters and identifier can be replayed to obtain the same values.
+// The identifier must be 1 to 64 characters long. It is supported by all generate methods of API release 4.
+func WithPregeneratedID(id string) GenerateOption {
+	return func(o *generateOptions) {
+		count := utf8.RuneCountInString(id)
+		if count < 1 || count > 64 {
+			o.setError(fmt.Errorf("%w: pregenerated id must be 1 to 64 characters long", ErrParamRange))
+			return
+		}
+
+		o.pregeneratedRandomization = map[string]string{"id": id}
+	}
+}
+
+// WithTicket uses the ticket with the given id for the request, see CreateTickets.
+// It is supported by the signed generate methods of API release 4.
+func WithTicket(ticketID string) GenerateOption {
+	return func(o *generateOptions) {
+		if ticketID == "" {
+			o.setError(fmt.Errorf("%w: empty ticket id", ErrParamRange))
+			return
+		}
+
+		o.ticketID = ticketID
+	}
+}
+
+// WithUserData attaches arbitrary data to the request, which random.org includes in the signed random object.
+// The data is encoded as JSON and must not be longer than 1000 characters. Use SignedResult.DecodeUserData
+// to read it back. It is supported by the signed generate methods.
+func WithUserData(data interface{}) GenerateOption {
+	return func(o *generateOptions) {
+		userData, err := json.Marshal(data)
+		if err != nil {
+			o.setError(fmt.Errorf("%w: user data: %v", ErrParamRange, err))
+			return
+		}
+		if utf8.RuneCount(userData) > 1000 {
+			o.setError(fmt.Errorf("%w: user data is longer than 1000 characters", ErrParamRange))
+			return
+		}
+
+		o.userData = userData
+	}
+}
+
+// WithLicenseData attaches the license data required by commercial API keys to the request, e.g. the maximum payout.
+// The data is encoded as JSON. It is supported by the signed generate methods of API release 4.
+func WithLicenseData(data interface{}) GenerateOption {
+	return func(o *generateOptions) {
+		licenseData, err := json.Marshal(data)
+		if err != nil {
+			o.setError(fmt.Errorf("%w: license data: %v", ErrParamRange, err))
+			return
+		}
+
+		o.licenseData = licenseData
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Request pacing
+// Responses contain an advisory delay the client should wait before sending its next request.
+
+// AdvisoryDelay returns how long the client should wait before sending its next request,
+// according to the advisory delay of the last response.
+func (r *Random) AdvisoryDelay() time.Duration {
+	r.mu.Lock()
+	delay := time.Until(r.nextRequest)
+	r.mu.Unlock()
+	if delay < 0 {
+		return 0
+	}
+
+	return delay
+}
+
+// waitForAdvisoryDelay reserves the next send slot and blocks until it is reached or the context is done.
+// Concurrent requests are spaced out by the last advisory delay, so that they are not all sent at once
+// when the delay has passed.
+func (r *Random) waitForAdvisoryDelay(ctx context.Context) error {
+	// do not reserve a slot that will never be used
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	sendAt := r.nextRequest
+	if now := time.Now(); sendAt.Before(now) {
+		sendAt = now
+	}
+	reserved := sendAt.Add(r.advisoryDelay)
+	r.nextRequest = reserved
+	r.mu.Unlock()
+
+	delay := time.Until(sendAt)
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// give the slot back unless a later request already reserved the one after it
+		r.mu.Lock()
+		if r.nextRequest.Equal(reserved) {
+			r.nextRequest = sendAt
+		}
+		r.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// saveAdvisoryDelay remembers the advisory delay of the given result object, if it has one.
+func (r *Random) saveAdvisoryDelay(rawResult json.RawMessage) {
+	result := struct {
+		AdvisoryDelay *int64 `json:"advisoryDelay"`
+	}{}
+	err := json.Unmarshal(rawResult, &result)
+	if err != nil || result.AdvisoryDelay == nil {
+		return
+	}
+
+	delay := time.Duration(*result.AdvisoryDelay) * time.Millisecond
+	r.mu.Lock()
+	r.advisoryDelay = delay
+	// do not move the next request before slots already reserved by concurrent requests
+	if nextRequest := time.Now().Add(delay); nextRequest.After(r.nextRequest) {
+		r.nextRequest = nextRequest
+	}
+	r.mu.Unlock()
+}
@@ -15,20 +15,25 @@
  *
  */
 
-// Package randomorg is a Random.org API client as described at https://api.random.org/json-rpc/2.
-// This is a third-party client. See https://github.com/sgade/randomorg.
+// Package randomorg is a Random.org API client as described at https://api.random.org/json-rpc/2
+// and https://api.random.org/json-rpc/4. This is a third-party client. See https://github.com/sgade/randomorg.
 // For any method documentation you should take a look at the official API documentation.
 // An API key can be acquired here: https://api.random.org/dashboard.
 package randomorg
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pborman/uuid"
@@ -36,8 +41,8 @@ import (
 
 // Private constants
 const (
-	// The Random.org API request endpoint URL
-	requestEndpoint = "https://api.random.org/json-rpc/2/invoke"
+	// The Random.org API request endpoint URL for a given API release
+	requestEndpointFormat = "https://api.random.org/json-rpc/%d/invoke"
 	// Example time format for ISO 8601
 	iso8601Example = time.RFC3339Nano //"2013-02-20 17:53:40Z"
 	// API Error template string
@@ -53,32 +58,81 @@ var (
 	// ErrParamRange is returned when invalid parameter ranges where given to a method.
 	// See the method API documentation for further details.
 	ErrParamRange = errors.New("invalid parameter range")
+	// ErrSignature is returned when a signature does not match its random object.
+	ErrSignature = errors.New("invalid signature")
+	// ErrArchiveVersion is returned when an archived envelope has a format version this package cannot read.
+	ErrArchiveVersion = errors.New("unsupported archive version")
+	// ErrVersion is returned when a method or parameter is not supported by the API release the client uses.
+	ErrVersion = errors.New("not supported by the api version")
 )
 
 // A Random defines a Random.org API Client.
 // For more information, see https://api.random.org/json-rpc/2.
+// A Random is safe for concurrent use by multiple goroutines.
 type Random struct {
+	// guards client, usage, advisoryDelay and nextRequest
+	mu sync.Mutex
 	// the api key
 	apiKey string
 	// reusable http.Client
 	client *http.Client
+	// the API release requests are made against
+	version APIVersion
+	// the request endpoint URL
+	endpoint string
+	// the User-Agent header sent with requests, if any
+	userAgent string
+	// the timeout of a single request, if any
+	timeout time.Duration
+	// the policy failed requests are retried with
+	retry RetryPolicy
+	// whether requests wait for the advisory delay of the previous response
+	pacing bool
+	// the advisory delay of the last response, which concurrent requests are spaced out by
+	advisoryDelay time.Duration
+	// the earliest time the next request should be sent at, as advised by the API
+	nextRequest time.Time
 	// usage cache
 	usage *Usage
 }
 
-// NewRandom creates a new Random client with the given apiKey.
-func NewRandom(apiKey string) *Random {
+// New creates a new Random client with the given apiKey and options.
+// It returns ErrAPIKey if no api key was given and ErrVersion if an unknown API release was selected.
+func New(apiKey string, options ...Option) (*Random, error) {
 	// check the api key
 	if apiKey == "" {
-		panic(ErrAPIKey)
+		return nil, ErrAPIKey
 	}
 
 	random := Random{
-		apiKey: apiKey,
-		client: &http.Client{},
+		apiKey:  apiKey,
+		client:  &http.Client{},
+		version: APIVersion2,
+		pacing:  true,
 	}
+	for _, option := range options {
+		option(&random)
+	}
+
+	if random.version != APIVersion2 && random.version != APIVersion4 {
+		return nil, ErrVersion
+	}
+	if random.endpoint == "" {
+		random.endpoint = fmt.Sprintf(requestEndpointFormat, random.version)
+	}
+
+	return &random, nil
+}
 
-	return &random
+// NewRandom creates a new Random client with the given apiKey and options.
+// It panics if no api key was given; use New to get an error instead.
+func NewRandom(apiKey string, options ...Option) *Random {
+	random, err := New(apiKey, options...)
+	if err != nil {
+		panic(err)
+	}
+
+	return random
 }
 
 // SetProxy sets the proxy for requests indicated by the url.
@@ -87,9 +141,11 @@ func (r *Random) SetProxy(proxyURL *url.URL) error {
 		Proxy: http.ProxyURL(proxyURL),
 	}
 
+	r.mu.Lock()
 	r.client = &http.Client{
 		Transport: t,
 	}
+	r.mu.Unlock()
 
 	return nil
 }
@@ -113,45 +169,148 @@ func (r *Random) SetProxyAddress(proxyAddress string) error {
 func (r *Random) jsonMap(json map[string]interface{}, key string) (map[string]interface{}, error) {
 	value := json[key]
 	if value == nil {
-		return nil, ErrJSONFormat
+		return nil, jsonFormatError("missing %q", key)
 	}
 
 	newMap, ok := value.(map[string]interface{})
 	if !ok {
-		return nil, ErrJSONFormat
+		return nil, jsonFormatError("%q is %T, want object", key, value)
 	}
 
 	return newMap, nil
 }
 
-func (r *Random) invokeRequest(method string, params map[string]interface{}) (map[string]interface{}, error) {
-	// always append api key
-	params["apiKey"] = r.apiKey
+// A response is the JSON-RPC response envelope returned by the API.
+type response struct {
+	Result json.RawMessage `json:"result"`
+	Error  *APIError       `json:"error"`
+}
+
+// invokeRawRequest invokes the request and returns the result object exactly as it was received.
+func (r *Random) invokeRawRequest(ctx context.Context, method string, params map[string]interface{}) (json.RawMessage, error) {
+	err := r.checkVersion(method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// always append api key, except for verifySignature which does not take one
+	if method != "verifySignature" {
+		params["apiKey"] = r.apiKey
+	}
 
 	// generate request UUID
 	requestUUID := uuid.NewUUID().String()
-	// build request body
-	requestBody := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  method,
-		"params":  params,
-		"id":      requestUUID,
-	}
-	requestBodyJSON, err := json.Marshal(requestBody)
+	requestBody, err := encodeRequest(method, params, requestUUID)
 	if err != nil {
 		return nil, err
 	}
-	requestBodyReader := bytes.NewReader(requestBodyJSON)
 
-	req, err := http.NewRequest("POST", requestEndpoint, requestBodyReader)
+	// retries resend the same body, so all attempts share the request id
+	for attempt := 1; ; attempt++ {
+		result, err := r.sendRequest(ctx, method, requestBody)
+		if err == nil || !r.retry.shouldRetry(ctx, method, attempt, err) {
+			return result, err
+		}
+
+		err = r.retry.wait(ctx, attempt)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// encodeRequest returns the JSON-RPC request body for the method call.
+// Raw parameters (e.g. a random object) are written byte for byte, as encoding/json would compact them,
+// and HTML characters are not escaped for the same reason.
+func encodeRequest(method string, params map[string]interface{}, id string) ([]byte, error) {
+	body := &bytes.Buffer{}
+	encoder := json.NewEncoder(body)
+	encoder.SetEscapeHTML(false)
+	encode := func(v interface{}) error {
+		err := encoder.Encode(v)
+		if err != nil {
+			return err
+		}
+		// drop the newline Encode appends
+		body.Truncate(body.Len() - 1)
+		return nil
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	body.WriteString(`{"jsonrpc":"2.0","method":`)
+	err := encode(method)
+	if err != nil {
+		return nil, err
+	}
+	body.WriteString(`,"params":{`)
+	for i, key := range keys {
+		if i > 0 {
+			body.WriteByte(',')
+		}
+		err = encode(key)
+		if err != nil {
+			return nil, err
+		}
+		body.WriteByte(':')
+
+		if raw, ok := params[key].(json.RawMessage); ok {
+			if !json.Valid(raw) {
+				return nil, fmt.Errorf("%w: %s is not valid json", ErrParamRange, key)
+			}
+			body.Write(raw)
+			continue
+		}
+		err = encode(params[key])
+		if err != nil {
+			return nil, err
+		}
+	}
+	body.WriteString(`},"id":`)
+	err = encode(id)
+	if err != nil {
+		return nil, err
+	}
+	body.WriteByte('}')
+
+	return body.Bytes(), nil
+}
+
+// sendRequest sends a single request with the given body and returns the result object exactly as it was received.
+func (r *Random) sendRequest(ctx context.Context, method string, requestBody []byte) (json.RawMessage, error) {
+	if r.pacing {
+		err := r.waitForAdvisoryDelay(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", r.endpoint, bytes.NewReader(requestBody))
 	if err != nil {
 		return nil, err
 	}
 
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
+	if r.userAgent != "" {
+		req.Header.Set("User-Agent", r.userAgent)
+	}
+
+	r.mu.Lock()
+	client := r.client
+	r.mu.Unlock()
 
-	resp, err := r.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -161,35 +320,52 @@ func (r *Random) invokeRequest(method string, params map[string]interface{}) (ma
 	if err != nil {
 		return nil, err
 	}
-	responseBody := make(map[string]interface{})
+	responseBody := response{}
 	err = json.Unmarshal(body, &responseBody)
+	if err == nil && responseBody.Error != nil {
+		return nil, responseBody.Error
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, &HTTPError{StatusCode: resp.StatusCode, Body: string(body)}
+	}
 	if err != nil {
-		if len(body) > 0 {
-			err = errors.New(string(body))
-		}
+		return nil, jsonFormatError("%s: %v: %q", method, err, body)
+	}
 
-		return nil, err
+	if len(responseBody.Result) == 0 || string(responseBody.Result) == "null" {
+		return nil, jsonFormatError("%s: missing \"result\"", method)
 	}
+	r.saveAdvisoryDelay(responseBody.Result)
 
-	result, err := r.jsonMap(responseBody, "result")
-	if err != nil {
-		error, err := r.jsonMap(responseBody, "error")
-		if err != nil {
-			return nil, err
-		}
+	return responseBody.Result, nil
+}
 
-		errorCode, _ := error["code"]
-		errorMessage, _ := error["message"]
-		err = fmt.Errorf(errAPI, errorCode, errorMessage)
+// invokeRequest invokes the request and returns the decoded result object.
+func (r *Random) invokeRequest(ctx context.Context, method string, params map[string]interface{}) (map[string]interface{}, error) {
+	rawResult, err := r.invokeRawRequest(ctx, method, params)
+	if err != nil {
 		return nil, err
 	}
 
+	result := make(map[string]interface{})
+	err = unmarshalJSON(rawResult, &result)
+	if err != nil {
+		return nil, jsonFormatError("%s: %v", method, err)
+	}
+
 	return result, nil
 }
 
 // requestCommand invokes the request and parses all information down to the requested data block.
-func (r *Random) requestCommand(method string, params map[string]interface{}) ([]interface{}, error) {
-	result, err := r.invokeRequest(method, params)
+func (r *Random) requestCommand(ctx context.Context, method string, params map[string]interface{}) ([]interface{}, error) {
+	// tickets, user data and license data can only be used for signed requests
+	for _, signedParam := range []string{"ticketId", "userData", "licenseData"} {
+		if _, ok := params[signedParam]; ok {
+			return nil, fmt.Errorf("%w: %s does not take %s", ErrParamRange, method, signedParam)
+		}
+	}
+
+	result, err := r.invokeRequest(ctx, method, params)
 	if err != nil {
 		return nil, err
 	}
@@ -198,10 +374,44 @@ func (r *Random) requestCommand(method string, params map[string]interface{}) ([
 
 	random, err := r.jsonMap(result, "random")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%s: %w", method, err)
 	}
 
-	data := random["data"].([]interface{})
+	data, ok := random["data"].([]interface{})
+	if !ok {
+		return nil, jsonFormatError("%s: \"data\" is %T, want array", method, random["data"])
+	}
 
 	return data, nil
 }
+
+// unmarshalJSON is like json.Unmarshal but decodes numbers into interface values as json.Number,
+// so that no precision is lost.
+func unmarshalJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// jsonInt returns the integer value of a number decoded by unmarshalJSON.
+func jsonInt(value interface{}) (int, bool) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+
+	i, err := strconv.ParseInt(number.String(), 10, 0)
+	return int(i), err == nil
+}
+
+// jsonFormatError returns an error wrapping ErrJSONFormat that describes what was unexpected.
+func jsonFormatError(format string, a ...interface{}) error {
+	return fmt.Errorf("%w: "+format, append([]interface{}{ErrJSONFormat}, a...)...)
+}
+
+// parseTime parses the timestamps used by the API, e.g. "2013-02-20 17:53:40Z".
+func parseTime(value string) (time.Time, error) {
+	// fix so that we can parse it
+	value = strings.Replace(value, " ", "T", 1)
+	return time.Parse(iso8601Example, value)
+}
@@ -0,0 +1,833 @@
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// A fakeRequest is a JSON-RPC request as received by the fake server.
+type fakeRequest struct {
+	Method string                 `json:"method"`
+	Params map[string]interface{} `json:"params"`
+	ID     interface{}            `json:"id"`
+}
+
+// newFakeServer starts a local JSON-RPC server answering every request with the result of handle.
+func newFakeServer(t *testing.T, handle func(req fakeRequest, header http.Header) string) *httptest.Server {
+	return newFakeErrorServer(t, func(req fakeRequest, header http.Header) (string, *APIError) {
+		return handle(req, header), nil
+	})
+}
+
+// newFakeErrorServer is like newFakeServer but answers with the error returned by handle, if any.
+func newFakeErrorServer(t *testing.T, handle func(req fakeRequest, header http.Header) (string, *APIError)) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := fakeRequest{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, _ := json.Marshal(req.ID)
+
+		w.Header().Set("Content-Type", "application/json")
+		result, apiErr := handle(req, r.Header)
+		if apiErr != nil {
+			apiErrJSON, _ := json.Marshal(apiErr)
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","error":%s,"id":%s}`, apiErrJSON, id)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":%s}`, result, id)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestOptions(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Method != "generateIntegers" {
+			t.Errorf("method = %q, want generateIntegers", req.Method)
+		}
+		if req.Params["apiKey"] != "key" {
+			t.Errorf("apiKey = %v, want key", req.Params["apiKey"])
+		}
+		if agent := header.Get("User-Agent"); agent != "test-agent" {
+			t.Errorf("User-Agent = %q, want test-agent", agent)
+		}
+
+		return `{"random":{"data":[1,2,3],"completionTime":"2011-10-10 13:19:12Z"},"bitsUsed":16,"bitsLeft":199984,"requestsLeft":9999,"advisoryDelay":0}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL), WithHTTPClient(server.Client()), WithUserAgent("test-agent"))
+	values, err := random.GenerateIntegers(3, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 3 || values[0] != 1 || values[1] != 2 || values[2] != 3 {
+		t.Errorf("GenerateIntegers() = %v, want [1 2 3]", values)
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		time.Sleep(100 * time.Millisecond)
+		return `{}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL), WithTimeout(10*time.Millisecond))
+	if _, err := random.GenerateIntegers(1, 1, 3); err == nil {
+		t.Error("GenerateIntegers() = nil, want timeout error")
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	server := newFakeErrorServer(t, func(req fakeRequest, header http.Header) (string, *APIError) {
+		return "", &APIError{Code: 403, Message: "The API key you specified has exceeded its daily bit allowance", Data: []interface{}{"key"}}
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	_, err := random.GenerateIntegers(1, 1, 3)
+	if !errors.Is(err, ErrInsufficientBits) {
+		t.Fatalf("GenerateIntegers() = %v, want %v", err, ErrInsufficientBits)
+	}
+	if errors.Is(err, ErrInsufficientRequests) {
+		t.Errorf("errors.Is(%v, %v) = true, want false", err, ErrInsufficientRequests)
+	}
+
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("errors.As(%v) = false, want true", err)
+	}
+	if apiErr.Code != 403 || len(apiErr.Data) != 1 || apiErr.Data[0] != "key" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if apiErr.IsParameterError() {
+		t.Error("IsParameterError() = true, want false")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(""); err != ErrAPIKey {
+		t.Errorf("New(\"\") = %v, want %v", err, ErrAPIKey)
+	}
+	if _, err := New("key"); err != nil {
+		t.Errorf("New(\"key\") = %v, want nil", err)
+	}
+}
+
+func TestMalformedResponse(t *testing.T) {
+	results := []string{
+		`{"random":{"data":["1"]}}`,
+		`{"random":{"data":[1.5]}}`,
+		`{"random":{"data":{}}}`,
+		`{"random":[]}`,
+		`{}`,
+		`[]`,
+		`null`,
+	}
+
+	for _, result := range results {
+		server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+			return result
+		})
+
+		random := NewRandom("key", WithEndpoint(server.URL))
+		if _, err := random.GenerateIntegers(1, 1, 3); !errors.Is(err, ErrJSONFormat) {
+			t.Errorf("GenerateIntegers() with result %s = %v, want %v", result, err, ErrJSONFormat)
+		}
+	}
+
+	signedResults := []string{
+		`{"random":{"data":[1],"completionTime":"2011-10-10 13:19:12Z","serialNumber":1}}`,
+		`{"random":{"data":[1],"completionTime":"2011-10-10 13:19:12Z","serialNumber":1},"signature":""}`,
+		`{"random":{"completionTime":"2011-10-10 13:19:12Z","serialNumber":1},"signature":"c2ln"}`,
+		`{"random":{"data":null,"completionTime":"2011-10-10 13:19:12Z","serialNumber":1},"signature":"c2ln"}`,
+	}
+
+	for _, result := range signedResults {
+		server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+			return result
+		})
+
+		random := NewRandom("key", WithEndpoint(server.URL))
+		if _, err := random.GenerateSignedIntegers(1, 1, 3); !errors.Is(err, ErrJSONFormat) {
+			t.Errorf("GenerateSignedIntegers() with result %s = %v, want %v", result, err, ErrJSONFormat)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "<html>maintenance</html>")
+	}))
+	t.Cleanup(server.Close)
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateIntegers(1, 1, 3); !errors.Is(err, ErrJSONFormat) {
+		t.Errorf("GenerateIntegers() with non-JSON body = %v, want %v", err, ErrJSONFormat)
+	}
+}
+
+func TestPacing(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		return `{"random":{"data":[1]},"advisoryDelay":200}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if delay := random.AdvisoryDelay(); delay <= 0 || delay > 200*time.Millisecond {
+		t.Errorf("AdvisoryDelay() = %v, want (0, 200ms]", delay)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := random.GenerateIntegersContext(ctx, 1, 1, 3); err != context.DeadlineExceeded {
+		t.Errorf("GenerateIntegersContext() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	start := time.Now()
+	if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("paced request took %v, want at least 100ms", elapsed)
+	}
+
+	unpaced := NewRandom("key", WithEndpoint(server.URL), WithPacing(false))
+	start = time.Now()
+	for i := 0; i < 2; i++ {
+		if _, err := unpaced.GenerateIntegers(1, 1, 3); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Errorf("unpaced requests took %v, want less than 200ms", elapsed)
+	}
+}
+
+func TestPacingConcurrent(t *testing.T) {
+	var mu sync.Mutex
+	var sent []time.Time
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		mu.Lock()
+		sent = append(sent, time.Now())
+		mu.Unlock()
+		return `{"random":{"data":[1]},"advisoryDelay":100}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(sent, func(i, j int) bool { return sent[i].Before(sent[j]) })
+	for i := 1; i < len(sent); i++ {
+		if gap := sent[i].Sub(sent[i-1]); gap < 90*time.Millisecond {
+			t.Errorf("request %d was sent %v after the previous one, want at least 100ms", i, gap)
+		}
+	}
+}
+
+func TestPacingCanceled(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		return `{"random":{"data":[1]},"advisoryDelay":200}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+	// once the delay has passed, a canceled request must not reserve the next slot
+	time.Sleep(random.AdvisoryDelay())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := random.GenerateIntegersContext(ctx, 1, 1, 3); err != context.Canceled {
+		t.Errorf("GenerateIntegersContext() = %v, want %v", err, context.Canceled)
+	}
+	if delay := random.AdvisoryDelay(); delay != 0 {
+		t.Errorf("AdvisoryDelay() after canceled request = %v, want 0", delay)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"jsonrpc":"2.0","result":{"random":{"data":[1],"completionTime":"2011-10-10 13:19:12Z","serialNumber":1},"signature":"c2ln"},"id":1}`)
+	}))
+	defer server.Close()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	random := NewRandom("key", WithEndpoint(server.URL), WithRetryPolicy(policy))
+	if _, err := random.GenerateIntegers(1, 1, 3); err != nil {
+		t.Fatalf("GenerateIntegers() = %v, want nil", err)
+	}
+	if attempts != 3 {
+		t.Errorf("attempts = %d, want 3", attempts)
+	}
+
+	attempts = 0
+	httpErr := &HTTPError{}
+	if _, err := random.GenerateSignedIntegers(1, 1, 3); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("GenerateSignedIntegers() = %v, want HTTP 503", err)
+	}
+	if attempts != 1 {
+		t.Errorf("signed attempts = %d, want 1", attempts)
+	}
+
+	attempts = 0
+	policy.RetrySigned = true
+	random = NewRandom("key", WithEndpoint(server.URL), WithRetryPolicy(policy))
+	if _, err := random.GenerateSignedIntegers(1, 1, 3); err != nil {
+		t.Errorf("GenerateSignedIntegers() = %v, want nil", err)
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Method == "getUsage" {
+			return `{"status":"running","creationTime":"2013-02-01 17:53:40Z","bitsLeft":998532,"requestsLeft":199996,"totalBits":1646421,"totalRequests":65036}`
+		}
+		return `{"random":{"data":[1],"completionTime":"2011-10-10 13:19:12Z","serialNumber":1},"signature":"c2ln","bitsUsed":2,"bitsLeft":998530,"requestsLeft":199995,"advisoryDelay":0}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var err error
+			switch i % 5 {
+			case 0:
+				_, err = random.GenerateIntegers(1, 1, 3)
+			case 1:
+				_, err = random.GenerateSignedIntegers(1, 1, 3)
+			case 2:
+				_, err = random.GetUsage()
+			case 3:
+				_, err = random.Usage()
+			case 4:
+				err = random.SetProxy(nil)
+				random.AdvisoryDelay()
+			}
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	usage, err := random.Usage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Status != "running" || usage.TotalRequests != 65036 {
+		t.Errorf("Usage() = %+v", usage)
+	}
+}
+
+func TestUsageContext(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		return `{"status":"running","creationTime":"2013-02-01 17:53:40Z","bitsLeft":998532,"requestsLeft":199996,"totalBits":1646421,"totalRequests":65036}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := random.UsageContext(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("UsageContext() without cache = %v, want %v", err, context.Canceled)
+	}
+	if _, err := random.GetUsage(); err != nil {
+		t.Fatal(err)
+	}
+	if usage, err := random.UsageContext(ctx); err != nil || usage.BitsLeft != 998532 {
+		t.Errorf("UsageContext() with cache = %+v, %v", usage, err)
+	}
+}
+
+func TestWithoutReplacement(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Params["replacement"] != false {
+			t.Errorf("replacement = %v, want false", req.Params["replacement"])
+		}
+		return `{"random":{"data":[3,1,2]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateIntegers(3, 1, 3, WithoutReplacement()); err != nil {
+		t.Errorf("GenerateIntegers() = %v, want nil", err)
+	}
+	if _, err := random.GenerateIntegers(4, 1, 3, WithoutReplacement()); err != ErrParamRange {
+		t.Errorf("GenerateIntegers() with n > range = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateDecimalFractions(11, 1, WithoutReplacement()); err != ErrParamRange {
+		t.Errorf("GenerateDecimalFractions() with n > range = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateStrings(9, 3, "ab", WithoutReplacement()); err != ErrParamRange {
+		t.Errorf("GenerateStrings() with n > range = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateUUIDs(1, WithoutReplacement()); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateUUIDs() without replacement = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateGaussiansWithParams(GaussianParams{1, 0, 1, 2}, WithoutReplacement()); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateGaussiansWithParams() without replacement = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateSignedBlobs(1, 8, WithoutReplacement()); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateSignedBlobs() without replacement = %v, want %v", err, ErrParamRange)
+	}
+}
+
+func TestGenerateIntegersBase(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Params["base"] != float64(16) {
+			t.Errorf("base = %v, want 16", req.Params["base"])
+		}
+		return `{"random":{"data":["ff","0a"]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	values, err := random.GenerateIntegersBase(2, 0, 255, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ints, err := ParseIntegersBase(values, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ints) != 2 || ints[0] != 255 || ints[1] != 10 {
+		t.Errorf("ParseIntegersBase(%v) = %v, want [255 10]", values, ints)
+	}
+
+	if _, err := random.GenerateIntegersBase(2, 0, 255, 3); err != ErrParamRange {
+		t.Errorf("GenerateIntegersBase() with base 3 = %v, want %v", err, ErrParamRange)
+	}
+}
+
+func TestGenerateIntegerSequences(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Params["n"] != float64(2) {
+			t.Errorf("n = %v, want 2", req.Params["n"])
+		}
+		if _, ok := req.Params["length"].([]interface{}); !ok {
+			t.Errorf("length = %v, want array", req.Params["length"])
+		}
+		return `{"random":{"data":[[1,5,3],["1f"]]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	sequences := []IntegerSequence{
+		{Length: 3, Min: 1, Max: 6, WithoutReplacement: true},
+		{Length: 1, Min: 0, Max: 255, Base: 16},
+	}
+	values, err := random.GenerateMultiformIntegerSequences(sequences)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || len(values[0]) != 3 || values[0][1] != 5 || len(values[1]) != 1 || values[1][0] != 31 {
+		t.Errorf("GenerateMultiformIntegerSequences() = %v, want [[1 5 3] [31]]", values)
+	}
+
+	if _, err := random.GenerateIntegerSequences(2, 7, 1, 6, WithoutReplacement()); err != ErrParamRange {
+		t.Errorf("GenerateIntegerSequences() with length > range = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateIntegerSequences(2, 5001, 1, 6); err != ErrParamRange {
+		t.Errorf("GenerateIntegerSequences() with too many integers = %v, want %v", err, ErrParamRange)
+	}
+}
+
+func TestGenerateBlobBytes(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Params["size"] != float64(16) {
+			t.Errorf("size = %v, want 16", req.Params["size"])
+		}
+		if req.Params["format"] == string(BlobFormatHex) {
+			return `{"random":{"data":["cafe"]}}`
+		}
+		return `{"random":{"data":["yv4="]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	for _, options := range [][]GenerateOption{nil, {WithBlobFormat(BlobFormatHex)}} {
+		blobs, err := random.GenerateBlobBytes(1, 2, options...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(blobs) != 1 || string(blobs[0]) != "\xca\xfe" {
+			t.Errorf("GenerateBlobBytes() = %x, want [cafe]", blobs)
+		}
+	}
+
+	if _, err := random.GenerateBlobs(1, 16, WithBlobFormat("binary")); err != ErrParamRange {
+		t.Errorf("GenerateBlobs() with unknown format = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateIntegers(1, 1, 6, WithBlobFormat(BlobFormatHex)); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateIntegers() with blob format = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateSignedUUIDs(1, WithBlobFormat(BlobFormatHex)); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateSignedUUIDs() with blob format = %v, want %v", err, ErrParamRange)
+	}
+}
+
+func TestParseUUIDs(t *testing.T) {
+	uuids, err := ParseUUIDs([]string{"47ee2f26-5f9e-4b4f-a71c-5d8c2c4a12b1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(uuids) != 1 || uuids[0].String() != "47ee2f26-5f9e-4b4f-a71c-5d8c2c4a12b1" {
+		t.Errorf("ParseUUIDs() = %v", uuids)
+	}
+
+	invalid := []string{
+		"not a uuid",
+		// version 1
+		"47ee2f26-5f9e-1b4f-a71c-5d8c2c4a12b1",
+		// NCS variant
+		"47ee2f26-5f9e-4b4f-171c-5d8c2c4a12b1",
+	}
+	for _, value := range invalid {
+		if _, err := ParseUUIDs([]string{value}); !errors.Is(err, ErrJSONFormat) {
+			t.Errorf("ParseUUIDs(%q) = %v, want %v", value, err, ErrJSONFormat)
+		}
+	}
+}
+
+func TestGenerateDecimalFractionsRat(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		return `{"random":{"data":[0.12345678901234567891,1.5e-7]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	values, err := random.GenerateDecimalFractionsRat(2, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(values) != 2 || values[0].FloatString(20) != "0.12345678901234567891" || values[1].FloatString(8) != "0.00000015" {
+		t.Errorf("GenerateDecimalFractionsRat() = %v", values)
+	}
+}
+
+func TestGenerateGaussiansWithParams(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		if req.Params["mean"] != 0.5 || req.Params["standardDeviation"] != 0.25 {
+			t.Errorf("mean, standardDeviation = %v, %v, want 0.5, 0.25", req.Params["mean"], req.Params["standardDeviation"])
+		}
+		return `{"random":{"data":[0.4,0.7]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	if _, err := random.GenerateGaussiansWithParams(GaussianParams{N: 2, Mean: 0.5, StandardDeviation: 0.25, SignificantDigits: 2}); err != nil {
+		t.Error(err)
+	}
+
+	invalid := []GaussianParams{
+		{N: 2, Mean: 0, StandardDeviation: -1, SignificantDigits: 2},
+		{N: 2, Mean: 1e7, StandardDeviation: 1, SignificantDigits: 2},
+		{N: 2, Mean: math.NaN(), StandardDeviation: 1, SignificantDigits: 2},
+	}
+	for _, p := range invalid {
+		if _, err := random.GenerateGaussiansWithParams(p); err != ErrParamRange {
+			t.Errorf("GenerateGaussiansWithParams(%+v) = %v, want %v", p, err, ErrParamRange)
+		}
+	}
+}
+
+func TestValidateCharacters(t *testing.T) {
+	valid := []string{CharsetAlphanumeric, CharsetHex, CharsetBase32, CharsetUnambiguous, "äöüß€", "a"}
+	for _, characters := range valid {
+		if err := validateCharacters(characters); err != nil {
+			t.Errorf("validateCharacters(%q) = %v, want nil", characters, err)
+		}
+	}
+
+	// 80 distinct runes of two bytes each
+	runes := make([]rune, 80)
+	for i := range runes {
+		runes[i] = rune(0x400 + i)
+	}
+	if err := validateCharacters(string(runes)); err != nil {
+		t.Errorf("validateCharacters(80 runes) = %v, want nil", err)
+	}
+
+	invalid := []string{"", "abca", "\xff", string(append(runes, 'a'))}
+	for _, characters := range invalid {
+		if err := validateCharacters(characters); !errors.Is(err, ErrParamRange) {
+			t.Errorf("validateCharacters(%q) = %v, want %v", characters, err, ErrParamRange)
+		}
+	}
+}
+
+func TestPregeneratedRandomization(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		pregenerated, ok := req.Params["pregeneratedRandomization"].(map[string]interface{})
+		if !ok || (pregenerated["date"] != "2010-01-01" && pregenerated["id"] != "draw-42") {
+			t.Errorf("pregeneratedRandomization = %v", req.Params["pregeneratedRandomization"])
+		}
+		return `{"random":{"data":["47ee2f26-5f9e-4b4f-a71c-5d8c2c4a12b1"]}}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL), WithAPIVersion(APIVersion4))
+	for _, option := range []GenerateOption{WithPregeneratedDate("2010-01-01"), WithPregeneratedID("draw-42")} {
+		if _, err := random.GenerateUUIDs(1, option); err != nil {
+			t.Error(err)
+		}
+	}
+
+	tomorrow := time.Now().UTC().Add(24 * time.Hour).Format("2006-01-02")
+	invalid := []GenerateOption{
+		WithPregeneratedDate("01.01.2010"),
+		WithPregeneratedDate("2009-12-31"),
+		WithPregeneratedDate(tomorrow),
+		WithPregeneratedID(""),
+		WithPregeneratedID(strings.Repeat("x", 65)),
+	}
+	for _, option := range invalid {
+		if _, err := random.GenerateUUIDs(1, option); !errors.Is(err, ErrParamRange) {
+			t.Errorf("GenerateUUIDs() with invalid option = %v, want %v", err, ErrParamRange)
+		}
+	}
+}
+
+func TestAPIVersion(t *testing.T) {
+	if _, err := New("key", WithAPIVersion(3)); err != ErrVersion {
+		t.Errorf("New() with version 3 = %v, want %v", err, ErrVersion)
+	}
+
+	random := NewRandom("key", WithAPIVersion(APIVersion4))
+	if random.endpoint != "https://api.random.org/json-rpc/4/invoke" {
+		t.Errorf("endpoint = %q, want release 4 endpoint", random.endpoint)
+	}
+
+	random = NewRandom("key", WithEndpoint("http://localhost"))
+	if random.APIVersion() != APIVersion2 {
+		t.Errorf("APIVersion() = %v, want %v", random.APIVersion(), APIVersion2)
+	}
+	if _, err := random.GenerateUUIDs(1, WithPregeneratedID("draw-42")); !errors.Is(err, ErrVersion) {
+		t.Errorf("GenerateUUIDs() with pregenerated randomization on release 2 = %v, want %v", err, ErrVersion)
+	}
+	if err := random.checkVersion("generateSignedIntegers", map[string]interface{}{"userData": "draw-42"}); err != nil {
+		t.Errorf("checkVersion() with user data on release 2 = %v, want nil", err)
+	}
+}
+
+func TestTickets(t *testing.T) {
+	const ticket = `{"ticketId":"ffbd4e5ea1b4d5ac","hashedApiKey":"oT3AdLMVZKajz0pgW","showResult":true,"creationTime":"2020-01-01 10:00:00Z","usedTime":null,"serialNumber":null,"previousTicketId":null,"nextTicketId":null,"result":null}`
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		switch req.Method {
+		case "createTickets":
+			return "[" + ticket + "]"
+		case "getTicket":
+			return `{"ticketId":"ffbd4e5ea1b4d5ac","hashedApiKey":"oT3AdLMVZKajz0pgW","showResult":true,"creationTime":"2020-01-01 10:00:00Z","usedTime":"2020-01-01 10:05:00Z","serialNumber":7,"previousTicketId":null,"nextTicketId":"aa1b","result":{"random":{"method":"generateSignedIntegers","data":[4],"completionTime":"2020-01-01 10:05:00Z","serialNumber":7},"signature":"c2ln"}}`
+		case "generateSignedIntegers":
+			if req.Params["ticketId"] != "ffbd4e5ea1b4d5ac" {
+				t.Errorf("ticketId = %v, want ffbd4e5ea1b4d5ac", req.Params["ticketId"])
+			}
+			return `{"random":{"data":[4],"completionTime":"2020-01-01 10:05:00Z","serialNumber":7},"signature":"c2ln"}`
+		}
+		t.Errorf("unexpected method %q", req.Method)
+		return `{}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL), WithAPIVersion(APIVersion4))
+	tickets, err := random.CreateTickets(1, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tickets) != 1 || tickets[0].TicketID != "ffbd4e5ea1b4d5ac" || !tickets[0].UsedTime.IsZero() || tickets[0].Result != nil {
+		t.Errorf("CreateTickets() = %+v", tickets)
+	}
+
+	if _, err := random.GenerateSignedIntegers(1, 1, 6, WithTicket(tickets[0].TicketID)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := random.GenerateIntegers(1, 1, 6, WithTicket(tickets[0].TicketID)); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateIntegers() with ticket = %v, want %v", err, ErrParamRange)
+	}
+
+	used, err := random.GetTicket(tickets[0].TicketID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if used.SerialNumber != 7 || used.NextTicketID != "aa1b" || used.UsedTime.IsZero() || used.Result == nil || used.Result.Method != "generateSignedIntegers" {
+		t.Errorf("GetTicket() = %+v", used)
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		switch req.Params["serialNumber"] {
+		case float64(1):
+			return `{"random":{"method":"generateSignedIntegers","base":16,"data":["ff"],"completionTime":"2020-01-01 10:05:00Z","serialNumber":1},"signature":"c2ln"}`
+		case float64(2):
+			return `{"random":{"method":"generateSignedIntegerSequences","n":2,"base":[10,2],"data":[[3],["101"]],"completionTime":"2020-01-01 10:05:00Z","serialNumber":2},"signature":"c2ln"}`
+		case float64(4):
+			return `{"random":{"method":"generateSignedIntegers","hashedApiKey":"oT3AdLMVZKajz0pgW","n":1,"min":1,"max":100,"replacement":true,"base":10,"data":[42],"completionTime":"2020-01-01 10:05:00Z","serialNumber":4},"signature":"c2ln"}`
+		case float64(8):
+			return `{"random":{"method":"generateSignedIntegers","hashedApiKey":"oT3AdLMVZKajz0pgW","n":1,"min":1,"max":100,"replacement":true,"base":8,"data":["52"],"completionTime":"2020-01-01 10:05:00Z","serialNumber":8},"signature":"c2ln"}`
+		case float64(5):
+			return `{"random":{"method":"generateSignedIntegers","base":0,"data":[5],"completionTime":"2020-01-01 10:05:00Z","serialNumber":5},"signature":"c2ln"}`
+		case float64(6):
+			return `{"random":{"method":"generateSignedIntegerSequences","n":1,"base":37,"data":[[5]],"completionTime":"2020-01-01 10:05:00Z","serialNumber":6},"signature":"c2ln"}`
+		case float64(7):
+			return `{"random":{"method":"generateSignedIntegerSequences","n":2,"base":[10,1],"data":[[5],[6]],"completionTime":"2020-01-01 10:05:00Z","serialNumber":7},"signature":"c2ln"}`
+		}
+		return `{"random":{"method":"generateSignedStrings","data":["abc"],"completionTime":"2020-01-01 10:05:00Z","serialNumber":3},"signature":"c2ln"}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL), WithAPIVersion(APIVersion4))
+
+	result, err := random.GetResult(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integers, ok := result.(*SignedIntegersBase); !ok || len(integers.Data) != 1 || integers.Data[0] != "ff" {
+		t.Errorf("GetResult(1) = %#v, want *SignedIntegersBase", result)
+	}
+
+	result, err = random.GetResult(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sequences, ok := result.(*SignedIntegerSequences); !ok || len(sequences.Data) != 2 || sequences.Data[1][0] != 5 {
+		t.Errorf("GetResult(2) = %#v, want *SignedIntegerSequences", result)
+	}
+
+	result, err = random.GetResult(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strs, ok := result.(*SignedStrings); !ok || strs.Signed().SerialNumber != 3 {
+		t.Errorf("GetResult(3) = %#v, want *SignedStrings", result)
+	}
+
+	result, err = random.GetResult(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integers, ok := result.(*SignedIntegers); !ok || len(integers.Data) != 1 || integers.Data[0] != 42 {
+		t.Errorf("GetResult(4) = %#v, want *SignedIntegers", result)
+	}
+
+	result, err = random.GetResult(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if integers, ok := result.(*SignedIntegersBase); !ok || len(integers.Data) != 1 || integers.Data[0] != "52" {
+		t.Errorf("GetResult(8) = %#v, want *SignedIntegersBase", result)
+	}
+
+	for _, serialNumber := range []int{5, 6, 7} {
+		if _, err := random.GetResult(serialNumber); !errors.Is(err, ErrJSONFormat) {
+			t.Errorf("GetResult(%d) with invalid base = %v, want %v", serialNumber, err, ErrJSONFormat)
+		}
+	}
+	if _, err := parseIntegerStrings([]interface{}{json.Number("5")}, 0); !errors.Is(err, ErrJSONFormat) {
+		t.Errorf("parseIntegerStrings() with base 0 = %v, want %v", err, ErrJSONFormat)
+	}
+}
+
+func TestWithUserData(t *testing.T) {
+	server := newFakeServer(t, func(req fakeRequest, header http.Header) string {
+		userData, ok := req.Params["userData"].(map[string]interface{})
+		if !ok || userData["contest"] != "spring-2020" {
+			t.Errorf("userData = %v", req.Params["userData"])
+		}
+		return `{"random":{"data":[4],"userData":{"contest":"spring-2020"},"completionTime":"2020-01-01 10:05:00Z","serialNumber":1},"signature":"c2ln"}`
+	})
+
+	random := NewRandom("key", WithEndpoint(server.URL))
+	userData := map[string]string{"contest": "spring-2020"}
+	signed, err := random.GenerateSignedIntegers(1, 1, 6, WithUserData(userData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(signed.UserData) != `{"contest":"spring-2020"}` {
+		t.Errorf("UserData = %s", signed.UserData)
+	}
+
+	if _, err := random.GenerateIntegers(1, 1, 6, WithUserData(userData)); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateIntegers() with user data = %v, want %v", err, ErrParamRange)
+	}
+	if _, err := random.GenerateSignedIntegers(1, 1, 6, WithUserData(strings.Repeat("x", 1000))); !errors.Is(err, ErrParamRange) {
+		t.Errorf("GenerateSignedIntegers() with long user data = %v, want %v", err, ErrParamRange)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	const random = `{"method": "generateSignedIntegers",
+	"data": [1, 2], "userData": "<a & b>"}`
+
+	results := map[string]string{
+		"valid":   `{"authenticity":true}`,
+		"invalid": `{"authenticity":false}`,
+		"missing": `{}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := struct {
+			Method string `json:"method"`
+			Params struct {
+				APIKey    *string         `json:"apiKey"`
+				Random    json.RawMessage `json:"random"`
+				Signature string          `json:"signature"`
+			} `json:"params"`
+		}{}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if req.Method != "verifySignature" || req.Params.APIKey != nil {
+			t.Errorf("request = %+v, want verifySignature without api key", req)
+		}
+		if string(req.Params.Random) != random {
+			t.Errorf("random = %s, want %s", req.Params.Random, random)
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","result":%s,"id":1}`, results[req.Params.Signature])
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewRandom("key", WithEndpoint(server.URL))
+
+	authentic, err := client.VerifySignature(json.RawMessage(random), "valid")
+	if err != nil || !authentic {
+		t.Errorf("VerifySignature(valid) = %v, %v, want true", authentic, err)
+	}
+	authentic, err = client.VerifySignature(json.RawMessage(random), "invalid")
+	if err != nil || authentic {
+		t.Errorf("VerifySignature(invalid) = %v, %v, want false", authentic, err)
+	}
+	if _, err := client.VerifySignature(json.RawMessage(random), "missing"); !errors.Is(err, ErrJSONFormat) {
+		t.Errorf("VerifySignature(missing) = %v, want %v", err, ErrJSONFormat)
+	}
+	if _, err := client.VerifySignature(json.RawMessage(`{"data":`), "valid"); !errors.Is(err, ErrParamRange) {
+		t.Errorf("VerifySignature() of invalid json = %v, want %v", err, ErrParamRange)
+	}
+}
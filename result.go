@@ -0,0 +1,182 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// A Signed is a typed result of a signed method, e.g. *SignedIntegers.
+type Signed interface {
+	// Signed returns the signed random object of the result.
+	Signed() *SignedResult
+}
+
+// Signed returns s itself. It implements Signed for all typed signed results.
+func (s *SignedResult) Signed() *SignedResult {
+	return s
+}
+
+// GetResult retrieves the signed result with the given serial number, e.g. to recover from a crash during a draw.
+// The returned value has the same type the signed method that created it returned, e.g. *SignedIntegers for
+// GenerateSignedIntegers and *SignedIntegersBase for GenerateSignedIntegersBase. As the random object does not
+// tell the two apart, results of GenerateSignedIntegersBase in base 10 are returned as *SignedIntegers.
+// Results of unknown methods are returned as *SignedResult.
+// GetResult requires API release 4.
+func (r *Random) GetResult(serialNumber int) (Signed, error) {
+	return r.GetResultContext(context.Background(), serialNumber)
+}
+
+// GetResultContext is like GetResult but uses the given context for the request.
+func (r *Random) GetResultContext(ctx context.Context, serialNumber int) (Signed, error) {
+	if serialNumber < 1 {
+		return nil, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"serialNumber": serialNumber,
+	}
+
+	signed, data, err := r.requestSignedCommand(ctx, "getResult", params)
+	if err != nil {
+		return nil, err
+	}
+
+	typed, err := newTypedSigned(signed, data)
+	if err != nil {
+		return nil, fmt.Errorf("getResult: %w", err)
+	}
+
+	return typed, nil
+}
+
+// newTypedSigned returns the typed result of the method that created the signed random object.
+func newTypedSigned(signed *SignedResult, data []interface{}) (Signed, error) {
+	random := struct {
+		Base json.RawMessage `json:"base"`
+	}{}
+	err := json.Unmarshal(signed.Random, &random)
+	if err != nil {
+		return nil, jsonFormatError("random object: %v", err)
+	}
+
+	switch signed.Method {
+	case "generateSignedIntegers":
+		// the base is recorded for every result, but only bases other than 10 return the data as strings
+		base := 10
+		if len(random.Base) > 0 {
+			err = json.Unmarshal(random.Base, &base)
+			if err != nil {
+				return nil, jsonFormatError("random object: base: %v", err)
+			}
+			if !validBase(base) {
+				return nil, jsonFormatError("random object: base is %d, want 2, 8, 10 or 16", base)
+			}
+		}
+		if base != 10 {
+			values, err := parseIntegerStrings(data, base)
+			if err != nil {
+				return nil, err
+			}
+			return &SignedIntegersBase{*signed, values}, nil
+		}
+		values, err := parseIntegers(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedIntegers{*signed, values}, nil
+	case "generateSignedIntegerSequences":
+		sequences, err := signedSequences(random.Base, len(data))
+		if err != nil {
+			return nil, err
+		}
+		values, err := parseIntegerSequences(data, sequences)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedIntegerSequences{*signed, values}, nil
+	case "generateSignedDecimalFractions":
+		values, err := parseFloats(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedDecimalFractions{*signed, values}, nil
+	case "generateSignedGaussians":
+		values, err := parseFloats(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedGaussians{*signed, values}, nil
+	case "generateSignedStrings":
+		values, err := parseStrings(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedStrings{*signed, values}, nil
+	case "generateSignedUUIDs":
+		values, err := parseStrings(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedUUIDs{*signed, values}, nil
+	case "generateSignedBlobs":
+		values, err := parseStrings(data)
+		if err != nil {
+			return nil, err
+		}
+		return &SignedBlobs{*signed, values}, nil
+	}
+
+	return signed, nil
+}
+
+// signedSequences returns the sequences with the bases given by the base parameter of a random object,
+// which is either missing, a single base or one base per sequence.
+func signedSequences(rawBase json.RawMessage, n int) ([]IntegerSequence, error) {
+	sequences := make([]IntegerSequence, n)
+	if len(rawBase) == 0 {
+		return sequences, nil
+	}
+
+	base := 0
+	if json.Unmarshal(rawBase, &base) == nil {
+		if !validBase(base) {
+			return nil, jsonFormatError("random object: base is %d, want 2, 8, 10 or 16", base)
+		}
+		for i := range sequences {
+			sequences[i].Base = base
+		}
+		return sequences, nil
+	}
+
+	bases := []int{}
+	err := json.Unmarshal(rawBase, &bases)
+	if err != nil || len(bases) != n {
+		return nil, jsonFormatError("random object: base is %s, want %d bases", rawBase, n)
+	}
+	for i := range sequences {
+		if !validBase(bases[i]) {
+			return nil, jsonFormatError("random object: base %d is %d, want 2, 8, 10 or 16", i, bases[i])
+		}
+		sequences[i].Base = bases[i]
+	}
+
+	return sequences, nil
+}
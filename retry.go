@@ -0,0 +1,122 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+)
+
+// A RetryPolicy defines how requests that failed with a transient error are retried.
+// Network errors and HTTP 5xx responses are considered transient, as are API errors with one of the RetryableCodes.
+// A retry resends the identical request, including its JSON-RPC id.
+type RetryPolicy struct {
+	// The maximum number of attempts, including the first one. Values below 2 disable retries.
+	MaxAttempts int
+	// The delay before the first retry.
+	InitialBackoff time.Duration
+	// The maximum delay between two attempts. Zero means no maximum.
+	MaxBackoff time.Duration
+	// The factor the delay grows by after each retry. Values below 1 keep the delay constant.
+	Multiplier float64
+	// The fraction of the delay, between 0 and 1, that is randomized to spread out retries.
+	Jitter float64
+	// The API error codes that are retried.
+	RetryableCodes []int
+	// Whether signed methods are retried. A retried signed request may produce more than one signed random object
+	// (with different serial numbers) of which only the last is returned, so this has to be enabled explicitly.
+	RetrySigned bool
+}
+
+// DefaultRetryPolicy is a RetryPolicy suitable for most clients.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 500 * time.Millisecond,
+	MaxBackoff:     10 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+	RetryableCodes: []int{ErrInternal.Code},
+}
+
+// shouldRetry reports whether the request that failed with err at the given attempt should be retried.
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, attempt int, err error) bool {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	if isSignedMethod(method) && !p.RetrySigned {
+		return false
+	}
+
+	apiErr := &APIError{}
+	if errors.As(err, &apiErr) {
+		for _, code := range p.RetryableCodes {
+			if apiErr.Code == code {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	httpErr := &HTTPError{}
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500
+	}
+
+	// network errors, including connections closed while reading the response
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// backoff returns the delay after the given attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := math.Max(p.Multiplier, 1)
+	delay := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 {
+		delay = math.Min(delay, float64(p.MaxBackoff))
+	}
+
+	jitter := math.Min(math.Max(p.Jitter, 0), 1)
+	delay -= delay * jitter * rand.Float64()
+
+	return time.Duration(delay)
+}
+
+// wait blocks for the backoff after the given attempt or until the context is done.
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	timer := time.NewTimer(p.backoff(attempt))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// isSignedMethod reports whether the method creates signed random objects or tickets.
+func isSignedMethod(method string) bool {
+	return strings.HasPrefix(method, "generateSigned") || method == "createTickets"
+}
@@ -0,0 +1,482 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Signed commands
+// see https://api.random.org/json-rpc/2/signed
+
+// SignedResult holds the signed random object returned by a signed method.
+type SignedResult struct {
+	// The random object exactly as it was returned by the API. The signature is computed over these bytes.
+	Random json.RawMessage
+	// The base64 encoded SHA-512 signature of the random object, signed with random.org's private key.
+	Signature string
+	// The serial number of the random object. Serial numbers increase with every signed request of an API key.
+	SerialNumber int
+	// The time at which the request was completed.
+	CompletionTime time.Time
+	// The name of the method that generated the random object.
+	Method string
+	// The base64 encoded SHA-512 hash of the API key that was used.
+	HashedAPIKey string
+	// The license the values were issued under. Only set by API release 4.
+	License *License
+	// The user data attached to the request with WithUserData, or nil.
+	UserData json.RawMessage
+
+	// the method and parameters (without the api key) of the request that fetched the result, for archiving
+	requestMethod string
+	requestParams json.RawMessage
+	// the time the result was received at
+	fetchedAt time.Time
+}
+
+// A License describes the terms under which the values of a signed result may be used.
+type License struct {
+	// The type of the license, e.g. "developer".
+	Type string `json:"type"`
+	// A human-readable description of the license.
+	Text string `json:"text"`
+	// A URL with further information about the license.
+	InfoURL string `json:"infoUrl"`
+}
+
+// SignedIntegers holds the result of GenerateSignedIntegers.
+type SignedIntegers struct {
+	SignedResult
+	Data []int64
+}
+
+// SignedIntegersBase holds the result of GenerateSignedIntegersBase.
+type SignedIntegersBase struct {
+	SignedResult
+	Data []string
+}
+
+// SignedIntegerSequences holds the result of GenerateSignedIntegerSequences and GenerateSignedMultiformIntegerSequences.
+type SignedIntegerSequences struct {
+	SignedResult
+	Data [][]int64
+}
+
+// SignedDecimalFractions holds the result of GenerateSignedDecimalFractions.
+type SignedDecimalFractions struct {
+	SignedResult
+	Data []float64
+}
+
+// SignedGaussians holds the result of GenerateSignedGaussiansWithParams.
+type SignedGaussians struct {
+	SignedResult
+	Data []float64
+}
+
+// SignedStrings holds the result of GenerateSignedStrings.
+type SignedStrings struct {
+	SignedResult
+	Data []string
+}
+
+// SignedUUIDs holds the result of GenerateSignedUUIDs.
+type SignedUUIDs struct {
+	SignedResult
+	Data []string
+}
+
+// SignedBlobs holds the result of GenerateSignedBlobs.
+type SignedBlobs struct {
+	SignedResult
+	Data []string
+}
+
+// GenerateSignedIntegers generates n number of random integers in the range from min to max and signs them.
+func (r *Random) GenerateSignedIntegers(n int, min, max int64, options ...GenerateOption) (*SignedIntegers, error) {
+	return r.GenerateSignedIntegersContext(context.Background(), n, min, max, options...)
+}
+
+// GenerateSignedIntegersContext is like GenerateSignedIntegers but uses the given context for the request.
+func (r *Random) GenerateSignedIntegersContext(ctx context.Context, n int, min, max int64, options ...GenerateOption) (*SignedIntegers, error) {
+	params, err := integersParams(n, min, max, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedIntegers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseIntegers(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedIntegers{*signed, data}, nil
+}
+
+// GenerateSignedIntegersBase generates n number of random integers in the range from min to max
+// formatted in the given base and signs them.
+func (r *Random) GenerateSignedIntegersBase(n int, min, max int64, base int, options ...GenerateOption) (*SignedIntegersBase, error) {
+	return r.GenerateSignedIntegersBaseContext(context.Background(), n, min, max, base, options...)
+}
+
+// GenerateSignedIntegersBaseContext is like GenerateSignedIntegersBase but uses the given context for the request.
+func (r *Random) GenerateSignedIntegersBaseContext(ctx context.Context, n int, min, max int64, base int, options ...GenerateOption) (*SignedIntegersBase, error) {
+	params, err := integersBaseParams(n, min, max, base, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedIntegers", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseIntegerStrings(values, base)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedIntegersBase{*signed, data}, nil
+}
+
+// GenerateSignedIntegerSequences generates n sequences of random integers, each with length integers in the range
+// from min to max, and signs them.
+func (r *Random) GenerateSignedIntegerSequences(n, length int, min, max int64, options ...GenerateOption) (*SignedIntegerSequences, error) {
+	return r.GenerateSignedIntegerSequencesContext(context.Background(), n, length, min, max, options...)
+}
+
+// GenerateSignedIntegerSequencesContext is like GenerateSignedIntegerSequences but uses the given context for the request.
+func (r *Random) GenerateSignedIntegerSequencesContext(ctx context.Context, n, length int, min, max int64, options ...GenerateOption) (*SignedIntegerSequences, error) {
+	if n < 1 || n > 1e3 {
+		return nil, ErrParamRange
+	}
+
+	sequences := make([]IntegerSequence, n)
+	for i := range sequences {
+		sequences[i] = IntegerSequence{Length: length, Min: min, Max: max}
+	}
+
+	return r.GenerateSignedMultiformIntegerSequencesContext(ctx, sequences, options...)
+}
+
+// GenerateSignedMultiformIntegerSequences generates sequences of random integers, each with its own length, range,
+// replacement and base, and signs them.
+func (r *Random) GenerateSignedMultiformIntegerSequences(sequences []IntegerSequence, options ...GenerateOption) (*SignedIntegerSequences, error) {
+	return r.GenerateSignedMultiformIntegerSequencesContext(context.Background(), sequences, options...)
+}
+
+// GenerateSignedMultiformIntegerSequencesContext is like GenerateSignedMultiformIntegerSequences but uses the given context for the request.
+func (r *Random) GenerateSignedMultiformIntegerSequencesContext(ctx context.Context, sequences []IntegerSequence, options ...GenerateOption) (*SignedIntegerSequences, error) {
+	params, err := integerSequencesParams(sequences, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedIntegerSequences", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseIntegerSequences(values, sequences)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedIntegerSequences{*signed, data}, nil
+}
+
+// GenerateSignedDecimalFractions generates n number of decimal fractions with decimalPlaces number of decimal places and signs them.
+func (r *Random) GenerateSignedDecimalFractions(n, decimalPlaces int, options ...GenerateOption) (*SignedDecimalFractions, error) {
+	return r.GenerateSignedDecimalFractionsContext(context.Background(), n, decimalPlaces, options...)
+}
+
+// GenerateSignedDecimalFractionsContext is like GenerateSignedDecimalFractions but uses the given context for the request.
+func (r *Random) GenerateSignedDecimalFractionsContext(ctx context.Context, n, decimalPlaces int, options ...GenerateOption) (*SignedDecimalFractions, error) {
+	params, err := decimalFractionsParams(n, decimalPlaces, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedDecimalFractions", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseFloats(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedDecimalFractions{*signed, data}, nil
+}
+
+// GenerateSignedGaussiansWithParams generates true random numbers from a Gaussian distribution and signs them.
+func (r *Random) GenerateSignedGaussiansWithParams(p GaussianParams, options ...GenerateOption) (*SignedGaussians, error) {
+	return r.GenerateSignedGaussiansWithParamsContext(context.Background(), p, options...)
+}
+
+// GenerateSignedGaussiansWithParamsContext is like GenerateSignedGaussiansWithParams but uses the given context for the request.
+func (r *Random) GenerateSignedGaussiansWithParamsContext(ctx context.Context, p GaussianParams, options ...GenerateOption) (*SignedGaussians, error) {
+	params, err := gaussiansParams(p, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedGaussians", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseFloats(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedGaussians{*signed, data}, nil
+}
+
+// GenerateSignedStrings generates n random strings with the given length composed from the characters and signs them.
+func (r *Random) GenerateSignedStrings(n, length int, characters string, options ...GenerateOption) (*SignedStrings, error) {
+	return r.GenerateSignedStringsContext(context.Background(), n, length, characters, options...)
+}
+
+// GenerateSignedStringsContext is like GenerateSignedStrings but uses the given context for the request.
+func (r *Random) GenerateSignedStringsContext(ctx context.Context, n, length int, characters string, options ...GenerateOption) (*SignedStrings, error) {
+	params, err := stringsParams(n, length, characters, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedStrings", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseStrings(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedStrings{*signed, data}, nil
+}
+
+// GenerateSignedUUIDs generates n random version 4 Universally Unique Identifiers and signs them.
+func (r *Random) GenerateSignedUUIDs(n int, options ...GenerateOption) (*SignedUUIDs, error) {
+	return r.GenerateSignedUUIDsContext(context.Background(), n, options...)
+}
+
+// GenerateSignedUUIDsContext is like GenerateSignedUUIDs but uses the given context for the request.
+func (r *Random) GenerateSignedUUIDsContext(ctx context.Context, n int, options ...GenerateOption) (*SignedUUIDs, error) {
+	params, err := uuidsParams(n, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedUUIDs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseStrings(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedUUIDs{*signed, data}, nil
+}
+
+// GenerateSignedBlobs generates n random blobs of size bits and signs them.
+// Use DecodeBlobs to decode them.
+func (r *Random) GenerateSignedBlobs(n, size int, options ...GenerateOption) (*SignedBlobs, error) {
+	return r.GenerateSignedBlobsContext(context.Background(), n, size, options...)
+}
+
+// GenerateSignedBlobsContext is like GenerateSignedBlobs but uses the given context for the request.
+func (r *Random) GenerateSignedBlobsContext(ctx context.Context, n, size int, options ...GenerateOption) (*SignedBlobs, error) {
+	params, err := blobsParams(n, size, options)
+	if err != nil {
+		return nil, err
+	}
+
+	signed, values, err := r.requestSignedCommand(ctx, "generateSignedBlobs", params)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := parseStrings(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignedBlobs{*signed, data}, nil
+}
+
+// VerifySignature verifies with random.org that the signature belongs to the random object.
+// The random object has to be given exactly as it was returned by the API, e.g. SignedResult.Random.
+func (r *Random) VerifySignature(random json.RawMessage, signature string) (bool, error) {
+	return r.VerifySignatureContext(context.Background(), random, signature)
+}
+
+// VerifySignatureContext is like VerifySignature but uses the given context for the request.
+func (r *Random) VerifySignatureContext(ctx context.Context, random json.RawMessage, signature string) (bool, error) {
+	if !json.Valid(random) {
+		return false, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"random":    random,
+		"signature": signature,
+	}
+
+	rawResult, err := r.invokeRawRequest(ctx, "verifySignature", params)
+	if err != nil {
+		return false, err
+	}
+
+	result := struct {
+		Authenticity *bool `json:"authenticity"`
+	}{}
+	err = json.Unmarshal(rawResult, &result)
+	if err != nil {
+		return false, jsonFormatError("verifySignature: %v", err)
+	}
+	if result.Authenticity == nil {
+		return false, jsonFormatError("verifySignature: missing \"authenticity\"")
+	}
+
+	return *result.Authenticity, nil
+}
+
+// requestSignedCommand invokes the request and returns the signed random object along with its data block.
+func (r *Random) requestSignedCommand(ctx context.Context, method string, params map[string]interface{}) (*SignedResult, []interface{}, error) {
+	rawResult, err := r.invokeRawRequest(ctx, method, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make(map[string]interface{})
+	err = unmarshalJSON(rawResult, &result)
+	if err != nil {
+		return nil, nil, jsonFormatError("%s: %v", method, err)
+	}
+	r.parseAndSaveUsage(result)
+
+	signed, data, err := parseSignedResult(rawResult)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", method, err)
+	}
+
+	// remember the request for archiving, but never the api key
+	if method == "getResult" {
+		// a retrieved result is archived with the request that generated it
+		signed.requestMethod = signed.Method
+		signed.requestParams, err = randomRequestParams(signed.Random)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", method, err)
+		}
+	} else {
+		requestParams := make(map[string]interface{}, len(params))
+		for key, value := range params {
+			if key != "apiKey" {
+				requestParams[key] = value
+			}
+		}
+		signed.requestMethod = method
+		signed.requestParams, err = json.Marshal(requestParams)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	signed.fetchedAt = time.Now()
+
+	return signed, data, nil
+}
+
+// parseSignedResult parses a result object holding a random object and its signature.
+func parseSignedResult(rawResult json.RawMessage) (*SignedResult, []interface{}, error) {
+	signedResult := struct {
+		Random    json.RawMessage `json:"random"`
+		Signature string          `json:"signature"`
+	}{}
+	err := json.Unmarshal(rawResult, &signedResult)
+	if err != nil {
+		return nil, nil, jsonFormatError("%v", err)
+	}
+	if len(signedResult.Random) == 0 {
+		return nil, nil, jsonFormatError("missing \"random\"")
+	}
+	if signedResult.Signature == "" {
+		return nil, nil, jsonFormatError("missing \"signature\"")
+	}
+
+	signed, data, err := parseSignedRandom(signedResult.Random)
+	if err != nil {
+		return nil, nil, err
+	}
+	signed.Signature = signedResult.Signature
+
+	return signed, data, nil
+}
+
+// parseSignedRandom parses the given random object into a SignedResult and its data block.
+func parseSignedRandom(rawRandom json.RawMessage) (*SignedResult, []interface{}, error) {
+	random := struct {
+		Method         string          `json:"method"`
+		HashedAPIKey   string          `json:"hashedApiKey"`
+		SerialNumber   int             `json:"serialNumber"`
+		CompletionTime string          `json:"completionTime"`
+		License        *License        `json:"license"`
+		UserData       json.RawMessage `json:"userData"`
+		Data           []interface{}   `json:"data"`
+	}{}
+	err := unmarshalJSON(rawRandom, &random)
+	if err != nil {
+		return nil, nil, jsonFormatError("random object: %v", err)
+	}
+
+	if random.Data == nil {
+		return nil, nil, jsonFormatError("random object: missing \"data\"")
+	}
+
+	completionTime, err := parseTime(random.CompletionTime)
+	if err != nil {
+		return nil, nil, jsonFormatError("random object: %v", err)
+	}
+
+	signed := &SignedResult{
+		Random:         rawRandom,
+		SerialNumber:   random.SerialNumber,
+		CompletionTime: completionTime,
+		Method:         random.Method,
+		HashedAPIKey:   random.HashedAPIKey,
+		License:        random.License,
+	}
+	if len(random.UserData) > 0 && string(random.UserData) != "null" {
+		signed.UserData = random.UserData
+	}
+
+	return signed, random.Data, nil
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Ticket commands
+// see https://api.random.org/json-rpc/4/signed
+// Tickets require API release 4.
+
+// A Ticket allows to commit to a signed request before it is made. A ticket is used by passing its id
+// to a signed generate method with the WithTicket option.
+type Ticket struct {
+	// The unique id of the ticket.
+	TicketID string
+	// The base64 encoded SHA-512 hash of the API key the ticket belongs to.
+	HashedAPIKey string
+	// Whether the result of the request the ticket is used for is shown to anyone who looks up the ticket.
+	ShowResult bool
+	// The time at which the ticket was created.
+	CreationTime time.Time
+	// The time at which the ticket was used, or the zero time if it is unused.
+	UsedTime time.Time
+	// The time at which the ticket expires, or the zero time if it does not expire.
+	ExpirationTime time.Time
+	// The serial number of the signed result the ticket was used for, or zero if it is unused.
+	SerialNumber int
+	// The id of the previous ticket of the chain, or "" if this is the first one.
+	PreviousTicketID string
+	// The id of the next ticket of the chain, or "" if this is the last one.
+	NextTicketID string
+	// The signed result the ticket was used for. It is nil while the ticket is unused or its result is not shown.
+	Result *SignedResult
+}
+
+// A TicketType selects the tickets returned by ListTickets.
+type TicketType string
+
+// Ticket types.
+const (
+	// TicketTypeSingleton selects tickets that are not chained to other tickets.
+	TicketTypeSingleton TicketType = "singleton"
+	// TicketTypeHead selects the first tickets of chains.
+	TicketTypeHead TicketType = "head"
+	// TicketTypeTail selects the last tickets of chains.
+	TicketTypeTail TicketType = "tail"
+)
+
+// CreateTickets creates n unused tickets. If showResult is false, the results of the requests the tickets are
+// used for are hidden from anyone looking up the tickets until they are revealed with RevealTickets.
+func (r *Random) CreateTickets(n int, showResult bool) ([]Ticket, error) {
+	return r.CreateTicketsContext(context.Background(), n, showResult)
+}
+
+// CreateTicketsContext is like CreateTickets but uses the given context for the request.
+func (r *Random) CreateTicketsContext(ctx context.Context, n int, showResult bool) ([]Ticket, error) {
+	if n < 1 || n > 50 {
+		return nil, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"n":          n,
+		"showResult": showResult,
+	}
+
+	return r.requestTickets(ctx, "createTickets", params)
+}
+
+// RevealTickets shows the results of the given ticket and all previous tickets of its chain that were
+// created with showResult set to false. It returns the number of revealed tickets.
+func (r *Random) RevealTickets(ticketID string) (int, error) {
+	return r.RevealTicketsContext(context.Background(), ticketID)
+}
+
+// RevealTicketsContext is like RevealTickets but uses the given context for the request.
+func (r *Random) RevealTicketsContext(ctx context.Context, ticketID string) (int, error) {
+	if ticketID == "" {
+		return 0, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"ticketId": ticketID,
+	}
+
+	rawResult, err := r.invokeRawRequest(ctx, "revealTickets", params)
+	if err != nil {
+		return 0, err
+	}
+
+	result := struct {
+		TicketCount *int `json:"ticketCount"`
+	}{}
+	err = json.Unmarshal(rawResult, &result)
+	if err != nil {
+		return 0, jsonFormatError("revealTickets: %v", err)
+	}
+	if result.TicketCount == nil {
+		return 0, jsonFormatError("revealTickets: missing \"ticketCount\"")
+	}
+
+	return *result.TicketCount, nil
+}
+
+// ListTickets returns the tickets of the given type that belong to the API key.
+func (r *Random) ListTickets(ticketType TicketType) ([]Ticket, error) {
+	return r.ListTicketsContext(context.Background(), ticketType)
+}
+
+// ListTicketsContext is like ListTickets but uses the given context for the request.
+func (r *Random) ListTicketsContext(ctx context.Context, ticketType TicketType) ([]Ticket, error) {
+	if ticketType != TicketTypeSingleton && ticketType != TicketTypeHead && ticketType != TicketTypeTail {
+		return nil, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"ticketType": ticketType,
+	}
+
+	return r.requestTickets(ctx, "listTickets", params)
+}
+
+// GetTicket returns the ticket with the given id. The ticket does not have to belong to the client's API key.
+func (r *Random) GetTicket(ticketID string) (*Ticket, error) {
+	return r.GetTicketContext(context.Background(), ticketID)
+}
+
+// GetTicketContext is like GetTicket but uses the given context for the request.
+func (r *Random) GetTicketContext(ctx context.Context, ticketID string) (*Ticket, error) {
+	if ticketID == "" {
+		return nil, ErrParamRange
+	}
+
+	params := map[string]interface{}{
+		"ticketId": ticketID,
+	}
+
+	rawResult, err := r.invokeRawRequest(ctx, "getTicket", params)
+	if err != nil {
+		return nil, err
+	}
+
+	ticket, err := parseTicket(rawResult)
+	if err != nil {
+		return nil, fmt.Errorf("getTicket: %w", err)
+	}
+
+	return ticket, nil
+}
+
+// requestTickets invokes a request that returns a list of tickets.
+func (r *Random) requestTickets(ctx context.Context, method string, params map[string]interface{}) ([]Ticket, error) {
+	rawResult, err := r.invokeRawRequest(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	rawTickets := []json.RawMessage{}
+	err = json.Unmarshal(rawResult, &rawTickets)
+	if err != nil {
+		return nil, jsonFormatError("%s: %v", method, err)
+	}
+
+	tickets := make([]Ticket, len(rawTickets))
+	for i, rawTicket := range rawTickets {
+		ticket, err := parseTicket(rawTicket)
+		if err != nil {
+			return nil, fmt.Errorf("%s: ticket %d: %w", method, i, err)
+		}
+		tickets[i] = *ticket
+	}
+
+	return tickets, nil
+}
+
+// parseTicket parses a ticket object.
+func parseTicket(rawTicket json.RawMessage) (*Ticket, error) {
+	ticketJSON := struct {
+		TicketID         string          `json:"ticketId"`
+		HashedAPIKey     string          `json:"hashedApiKey"`
+		ShowResult       bool            `json:"showResult"`
+		CreationTime     string          `json:"creationTime"`
+		UsedTime         *string         `json:"usedTime"`
+		ExpirationTime   *string         `json:"expirationTime"`
+		SerialNumber     *int            `json:"serialNumber"`
+		PreviousTicketID *string         `json:"previousTicketId"`
+		NextTicketID     *string         `json:"nextTicketId"`
+		Result           json.RawMessage `json:"result"`
+	}{}
+	err := json.Unmarshal(rawTicket, &ticketJSON)
+	if err != nil {
+		return nil, jsonFormatError("%v", err)
+	}
+	if ticketJSON.TicketID == "" {
+		return nil, jsonFormatError("missing \"ticketId\"")
+	}
+
+	ticket := &Ticket{
+		TicketID:     ticketJSON.TicketID,
+		HashedAPIKey: ticketJSON.HashedAPIKey,
+		ShowResult:   ticketJSON.ShowResult,
+	}
+
+	ticket.CreationTime, err = parseTime(ticketJSON.CreationTime)
+	if err != nil {
+		return nil, jsonFormatError("creationTime: %v", err)
+	}
+	if ticketJSON.UsedTime != nil {
+		ticket.UsedTime, err = parseTime(*ticketJSON.UsedTime)
+		if err != nil {
+			return nil, jsonFormatError("usedTime: %v", err)
+		}
+	}
+	if ticketJSON.ExpirationTime != nil {
+		ticket.ExpirationTime, err = parseTime(*ticketJSON.ExpirationTime)
+		if err != nil {
+			return nil, jsonFormatError("expirationTime: %v", err)
+		}
+	}
+	if ticketJSON.SerialNumber != nil {
+		ticket.SerialNumber = *ticketJSON.SerialNumber
+	}
+	if ticketJSON.PreviousTicketID != nil {
+		ticket.PreviousTicketID = *ticketJSON.PreviousTicketID
+	}
+	if ticketJSON.NextTicketID != nil {
+		ticket.NextTicketID = *ticketJSON.NextTicketID
+	}
+	if len(ticketJSON.Result) > 0 && string(ticketJSON.Result) != "null" {
+		ticket.Result, _, err = parseSignedResult(ticketJSON.Result)
+		if err != nil {
+			return nil, fmt.Errorf("result: %w", err)
+		}
+	}
+
+	return ticket, nil
+}
@@ -18,7 +18,7 @@
 package randomorg
 
 import (
-	"strings"
+	"context"
 	"time"
 )
 
@@ -41,27 +41,30 @@ type Usage struct {
 	isComplete bool
 }
 
-func (r *Random) parseAndSaveUsage(json map[string]interface{}) {
+// parseAndSaveUsage updates the usage cache with the information of the given result object and returns a copy of it.
+func (r *Random) parseAndSaveUsage(json map[string]interface{}) Usage {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	usage := &Usage{}
 	if r.usage != nil {
-		usage = r.usage
+		// copy so that previously returned usages are not modified
+		cached := *r.usage
+		usage = &cached
 	}
 
 	isComplete := true
 
-	status, _ := json["status"]
-	if status != nil {
-		usage.Status = status.(string)
+	status, ok := json["status"].(string)
+	if ok {
+		usage.Status = status
 	} else {
 		isComplete = false
 	}
 
-	creationTimeValue, _ := json["creationTime"]
-	if creationTimeValue != nil {
-		creationTimeString := creationTimeValue.(string)
-		// fix so that we can parse it
-		creationTimeString = strings.Replace(creationTimeString, " ", "T", 1)
-		creationTime, err := time.Parse(iso8601Example, creationTimeString)
+	creationTimeValue, ok := json["creationTime"].(string)
+	if ok {
+		creationTime, err := parseTime(creationTimeValue)
 		if err == nil {
 			usage.CreationTime = creationTime
 		} else {
@@ -71,55 +74,76 @@ func (r *Random) parseAndSaveUsage(json map[string]interface{}) {
 		isComplete = false
 	}
 
-	bitsLeft, _ := json["bitsLeft"]
-	if bitsLeft != nil {
-		usage.BitsLeft = int(bitsLeft.(float64))
+	bitsLeft, ok := jsonInt(json["bitsLeft"])
+	if ok {
+		usage.BitsLeft = bitsLeft
 	} else {
 		isComplete = false
 	}
 
-	requestsLeft, _ := json["requestsLeft"]
-	if requestsLeft != nil {
-		usage.RequestsLeft = int(requestsLeft.(float64))
+	requestsLeft, ok := jsonInt(json["requestsLeft"])
+	if ok {
+		usage.RequestsLeft = requestsLeft
 	} else {
 		isComplete = false
 	}
 
-	totalBits, _ := json["totalBits"]
-	if totalBits != nil {
-		usage.TotalBits = int(totalBits.(float64))
+	totalBits, ok := jsonInt(json["totalBits"])
+	if ok {
+		usage.TotalBits = totalBits
 	} else {
 		isComplete = false
 	}
 
-	totalRequests, _ := json["totalRequests"]
-	if totalRequests != nil {
-		usage.TotalRequests = int(totalRequests.(float64))
+	totalRequests, ok := jsonInt(json["totalRequests"])
+	if ok {
+		usage.TotalRequests = totalRequests
 	} else {
 		isComplete = false
 	}
 
 	usage.isComplete = isComplete
 	r.usage = usage
+
+	return *usage
 }
 
 // GetUsage returns information related to the the usage of a given API key.
 func (r *Random) GetUsage() (Usage, error) {
+	return r.GetUsageContext(context.Background())
+}
+
+// GetUsageContext is like GetUsage but uses the given context for the request.
+func (r *Random) GetUsageContext(ctx context.Context) (Usage, error) {
 	params := map[string]interface{}{}
 
-	_, err := r.requestCommand("getUsage", params)
-	if err != nil && err != ErrJSONFormat {
+	result, err := r.invokeRequest(ctx, "getUsage", params)
+	if err != nil {
 		return Usage{}, err
 	}
 
-	return r.Usage()
+	usage := r.parseAndSaveUsage(result)
+	if !usage.isComplete {
+		return usage, jsonFormatError("getUsage: incomplete usage information")
+	}
+
+	return usage, nil
 }
 
 // Usage returns the API usage. This will return a cached version of the last request, if there is one.
 func (r *Random) Usage() (Usage, error) {
-	if r.usage != nil && r.usage.isComplete {
-		return *r.usage, nil
+	return r.UsageContext(context.Background())
+}
+
+// UsageContext is like Usage but uses the given context if a request has to be made.
+func (r *Random) UsageContext(ctx context.Context) (Usage, error) {
+	r.mu.Lock()
+	usage := r.usage
+	r.mu.Unlock()
+
+	if usage != nil && usage.isComplete {
+		return *usage, nil
 	}
 
-	return r.GetUsage()
+	return r.GetUsageContext(ctx)
 }
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+)
+
+// Offline signature verification
+// random.org signs the SHA-512 hash of the random object with its private key (PKCS #1 v1.5).
+// The matching public key is published by random.org and has to be supplied by the caller.
+
+// ParsePublicKey parses a PEM encoded RSA public key or X.509 certificate, such as the one published by random.org.
+func ParsePublicKey(data []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	var publicKey interface{}
+	var err error
+	switch block.Type {
+	case "CERTIFICATE":
+		var certificate *x509.Certificate
+		certificate, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			publicKey = certificate.PublicKey
+		}
+	case "RSA PUBLIC KEY":
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rsaPublicKey, ok := publicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("not an RSA public key")
+	}
+
+	return rsaPublicKey, nil
+}
+
+// Verify verifies offline that signature is a valid signature of the random object made with the private key
+// belonging to publicKey. The random object has to be given exactly as it was returned by the API.
+// It returns ErrSignature if the signature does not match.
+func Verify(publicKey *rsa.PublicKey, random json.RawMessage, signature string) error {
+	if publicKey == nil {
+		return errors.New("provide a public key")
+	}
+
+	signatureBytes, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return ErrSignature
+	}
+
+	hash := sha512.Sum512(random)
+	err = rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, hash[:], signatureBytes)
+	if err != nil {
+		return ErrSignature
+	}
+
+	return nil
+}
+
+// Verify verifies the signature of the signed result offline. See Verify for details.
+func (s *SignedResult) Verify(publicKey *rsa.PublicKey) error {
+	return Verify(publicKey, s.Random, s.Signature)
+}
+
+// DecodeUserData verifies the signature of the signed result offline and decodes its user data into v.
+// It returns ErrSignature if the signature does not match, so that only user data signed by random.org is returned.
+func (s *SignedResult) DecodeUserData(publicKey *rsa.PublicKey, v interface{}) error {
+	err := s.Verify(publicKey)
+	if err != nil {
+		return err
+	}
+	if s.UserData == nil {
+		return errors.New("no user data")
+	}
+
+	return json.Unmarshal(s.UserData, v)
+}
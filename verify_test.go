@@ -0,0 +1,162 @@
+package randomorg
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+const testRandom = `{"method":"generateSignedIntegers","hashedApiKey":"oT3AdLMVZKajz0pgW/8Z+t5sGZkqQSOnAi1aB8Li0tXgWf8LolrgdQ1wn9sKx1ehxhUZmhwUIpAtM8QeRbn51Q==","n":6,"min":1,"max":6,"replacement":true,"base":10,"data":[2,4,4,1,5,1],"completionTime":"2013-12-05 21:46:48Z","serialNumber":1}`
+
+func signTestRandom(t *testing.T, key *rsa.PrivateKey, random string) string {
+	hash := sha512.Sum512([]byte(random))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := signTestRandom(t, key, testRandom)
+
+	if err := Verify(&key.PublicKey, json.RawMessage(testRandom), signature); err != nil {
+		t.Errorf("Verify() = %v, want nil", err)
+	}
+
+	tampered := json.RawMessage(testRandom[:len(testRandom)-2] + "2}")
+	if err := Verify(&key.PublicKey, tampered, signature); err != ErrSignature {
+		t.Errorf("Verify() with tampered random = %v, want %v", err, ErrSignature)
+	}
+
+	if err := Verify(&key.PublicKey, json.RawMessage(testRandom), "not base64"); err != ErrSignature {
+		t.Errorf("Verify() with malformed signature = %v, want %v", err, ErrSignature)
+	}
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed := SignedResult{Random: json.RawMessage(testRandom), Signature: signature}
+	if err := signed.Verify(&other.PublicKey); err != ErrSignature {
+		t.Errorf("SignedResult.Verify() with other key = %v, want %v", err, ErrSignature)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks := []*pem.Block{
+		{Type: "PUBLIC KEY", Bytes: pkix},
+		{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)},
+	}
+
+	for _, block := range blocks {
+		publicKey, err := ParsePublicKey(pem.EncodeToMemory(block))
+		if err != nil {
+			t.Errorf("ParsePublicKey(%s) = %v", block.Type, err)
+			continue
+		}
+		if publicKey.N.Cmp(key.PublicKey.N) != 0 || publicKey.E != key.PublicKey.E {
+			t.Errorf("ParsePublicKey(%s) returned a different key", block.Type)
+		}
+	}
+
+	if _, err := ParsePublicKey([]byte("garbage")); err == nil {
+		t.Error("ParsePublicKey(garbage) = nil, want error")
+	}
+}
+
+func TestDecodeUserData(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	random := `{"method":"generateSignedIntegers","data":[4],"userData":{"contest":"spring-2020"},"completionTime":"2020-01-01 10:05:00Z","serialNumber":1}`
+	signed, _, err := parseSignedRandom(json.RawMessage(random))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed.Signature = signTestRandom(t, key, random)
+
+	userData := struct {
+		Contest string `json:"contest"`
+	}{}
+	if err := signed.DecodeUserData(&key.PublicKey, &userData); err != nil {
+		t.Fatal(err)
+	}
+	if userData.Contest != "spring-2020" {
+		t.Errorf("DecodeUserData() = %+v, want contest spring-2020", userData)
+	}
+
+	signed.Signature = signTestRandom(t, key, testRandom)
+	if err := signed.DecodeUserData(&key.PublicKey, &userData); err != ErrSignature {
+		t.Errorf("DecodeUserData() with wrong signature = %v, want %v", err, ErrSignature)
+	}
+}
+
+func TestVerificationURL(t *testing.T) {
+	signed := SignedResult{Random: json.RawMessage(testRandom), Signature: "c2ln+/=="}
+
+	verificationURL, err := signed.VerificationURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := url.Parse(verificationURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	random, err := base64.StdEncoding.DecodeString(parsed.Query().Get("random"))
+	if err != nil || string(random) != testRandom {
+		t.Errorf("random = %s, %v, want %s", random, err, testRandom)
+	}
+	if signature := parsed.Query().Get("signature"); signature != signed.Signature {
+		t.Errorf("signature = %q, want %q", signature, signed.Signature)
+	}
+
+	signed.Random = json.RawMessage(strings.Repeat(" ", 2046))
+	if _, err := signed.VerificationURL(); err != ErrURLLength {
+		t.Errorf("VerificationURL() of large result = %v, want %v", err, ErrURLLength)
+	}
+}
+
+func TestWriteCertificate(t *testing.T) {
+	signed, _, err := parseSignedRandom(json.RawMessage(testRandom))
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed.Signature = "c2ln"
+
+	certificate := &strings.Builder{}
+	if err := signed.WriteCertificate(certificate, "Raffle <2020>"); err != nil {
+		t.Fatal(err)
+	}
+
+	html := certificate.String()
+	for _, want := range []string{"Raffle &lt;2020&gt;", `name="signature" value="c2ln"`, "generateSignedIntegers", "2013-12-05 21:46:48Z"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("certificate does not contain %q", want)
+		}
+	}
+}
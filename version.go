@@ -0,0 +1,66 @@
+/*
+ * Copyright 2015 Sören Gade
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *    http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package randomorg
+
+import (
+	"fmt"
+)
+
+// An APIVersion is a release of the Random.org JSON-RPC API.
+type APIVersion int
+
+// Supported API releases.
+const (
+	// APIVersion2 is release 2 of the API, see https://api.random.org/json-rpc/2.
+	APIVersion2 APIVersion = 2
+	// APIVersion4 is release 4 of the API, see https://api.random.org/json-rpc/4.
+	// It adds tickets, result retrieval by serial number, pregenerated randomization
+	// and license data to the methods of release 2.
+	APIVersion4 APIVersion = 4
+)
+
+// Methods and parameters introduced with release 4.
+var (
+	version4Methods = []string{"createTickets", "revealTickets", "listTickets", "getTicket", "getResult"}
+	version4Params  = []string{"pregeneratedRandomization", "licenseData", "ticketId"}
+)
+
+// APIVersion returns the API release the client makes requests against.
+func (r *Random) APIVersion() APIVersion {
+	return r.version
+}
+
+// checkVersion returns ErrVersion if the method or one of the params is not supported by the client's API release.
+func (r *Random) checkVersion(method string, params map[string]interface{}) error {
+	if r.version >= APIVersion4 {
+		return nil
+	}
+
+	for _, v4Method := range version4Methods {
+		if method == v4Method {
+			return fmt.Errorf("%w: %s requires API version %d", ErrVersion, method, APIVersion4)
+		}
+	}
+	for _, v4Param := range version4Params {
+		if _, ok := params[v4Param]; ok {
+			return fmt.Errorf("%w: parameter %s requires API version %d", ErrVersion, v4Param, APIVersion4)
+		}
+	}
+
+	return nil
+}